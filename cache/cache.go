@@ -0,0 +1,256 @@
+package cache
+
+import "sync"
+
+type Config[K comparable, V any] struct {
+	// Policy selects the eviction policy. The default is LRU.
+	Policy Policy
+	// MaxEntries limits the number of entries. It is used when Weigher is
+	// nil.
+	MaxEntries int
+	// Weigher returns the weight of an entry, such as its size in bytes.
+	// When it is set, MaxWeight limits the total weight instead of
+	// MaxEntries limiting the number of entries.
+	Weigher   func(key K, value V) int64
+	MaxWeight int64
+	// OnEvict is called with every entry the policy evicts to make room.
+	// It is not called for Delete, Clear or overwritten values, and it runs
+	// after the cache lock is released.
+	OnEvict func(key K, value V)
+	// ThreadSafe guards every operation with a mutex.
+	ThreadSafe bool
+}
+
+// Stats counts how a Cache has been used.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// HitRatio returns the share of lookups that were hits, or 0 before the
+// first lookup.
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+type entry[V any] struct {
+	value  V
+	weight int64
+}
+
+type Cache[K comparable, V any] struct {
+	mu       sync.Mutex
+	config   Config[K, V]
+	entries  map[K]*entry[V]
+	policy   policy[K]
+	capacity int64
+	weight   int64
+	stats    Stats
+}
+
+// New creates an empty cache.
+//
+// It panics if the configured limit, MaxWeight with a Weigher or MaxEntries
+// without one, is not positive.
+// Returns a pointer to the new Cache.
+func New[K comparable, V any](config Config[K, V]) *Cache[K, V] {
+	capacity := int64(config.MaxEntries)
+	if config.Weigher != nil {
+		capacity = config.MaxWeight
+	}
+	if capacity <= 0 {
+		panic("cache: capacity must be positive")
+	}
+	return &Cache[K, V]{
+		config:   config,
+		entries:  make(map[K]*entry[V]),
+		policy:   newPolicy[K](config.Policy),
+		capacity: capacity,
+	}
+}
+
+// Get returns the value for key and whether it was found, counting a hit or
+// a miss and marking the entry as used.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.lock()
+	defer c.unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.policy.hit(key)
+	return e.value, true
+}
+
+// Peek returns the value for key like Get, without counting the lookup or
+// marking the entry as used.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	c.lock()
+	defer c.unlock()
+	if e, ok := c.entries[key]; ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains checks if key is cached, without marking it as used.
+func (c *Cache[K, V]) Contains(key K) bool {
+	c.lock()
+	defer c.unlock()
+	_, ok := c.entries[key]
+	return ok
+}
+
+// Set stores value for key, evicting other entries until it fits.
+//
+// Returns false if the entry alone is heavier than the whole cache. It is
+// not stored then, and any previous value for key is removed.
+func (c *Cache[K, V]) Set(key K, value V) bool {
+	c.lock()
+	w := c.weigh(key, value)
+	if w > c.capacity {
+		c.delete(key)
+		c.unlock()
+		return false
+	}
+
+	var evicted []evictedEntry[K, V]
+	if e, ok := c.entries[key]; ok {
+		c.weight += w - e.weight
+		e.value, e.weight = value, w
+		c.policy.hit(key)
+		evicted = c.makeRoom(key, 0)
+	} else {
+		c.policy.miss(key)
+		evicted = c.makeRoom(key, w)
+		c.entries[key] = &entry[V]{value: value, weight: w}
+		c.weight += w
+		c.policy.insert(key)
+	}
+	c.unlock()
+
+	if c.config.OnEvict != nil {
+		for _, e := range evicted {
+			c.config.OnEvict(e.key, e.value)
+		}
+	}
+	return true
+}
+
+// Delete removes key from the cache.
+//
+// Returns false if key was not cached.
+func (c *Cache[K, V]) Delete(key K) bool {
+	c.lock()
+	defer c.unlock()
+	return c.delete(key)
+}
+
+// Keys returns the cached keys in no particular order.
+func (c *Cache[K, V]) Keys() []K {
+	c.lock()
+	defer c.unlock()
+	keys := make([]K, 0, len(c.entries))
+	for k := range c.entries {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Len returns the number of cached entries.
+func (c *Cache[K, V]) Len() int {
+	c.lock()
+	defer c.unlock()
+	return len(c.entries)
+}
+
+// Weight returns the total weight of the cached entries, which is their
+// number when no Weigher is configured.
+func (c *Cache[K, V]) Weight() int64 {
+	c.lock()
+	defer c.unlock()
+	return c.weight
+}
+
+// Stats returns the hit, miss and eviction counts so far.
+func (c *Cache[K, V]) Stats() Stats {
+	c.lock()
+	defer c.unlock()
+	return c.stats
+}
+
+// ResetStats sets every count to zero.
+func (c *Cache[K, V]) ResetStats() {
+	c.lock()
+	defer c.unlock()
+	c.stats = Stats{}
+}
+
+// Clear removes every entry without calling OnEvict. Statistics are kept.
+func (c *Cache[K, V]) Clear() {
+	c.lock()
+	defer c.unlock()
+	c.entries = make(map[K]*entry[V])
+	c.policy.clear()
+	c.weight = 0
+}
+
+type evictedEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// makeRoom evicts entries other than key until extra more weight fits.
+func (c *Cache[K, V]) makeRoom(key K, extra int64) []evictedEntry[K, V] {
+	var evicted []evictedEntry[K, V]
+	for c.weight+extra > c.capacity {
+		victim, ok := c.policy.evict(key)
+		if !ok {
+			break
+		}
+		e := c.entries[victim]
+		delete(c.entries, victim)
+		c.weight -= e.weight
+		c.stats.Evictions++
+		evicted = append(evicted, evictedEntry[K, V]{victim, e.value})
+	}
+	return evicted
+}
+
+func (c *Cache[K, V]) delete(key K) bool {
+	e, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	delete(c.entries, key)
+	c.weight -= e.weight
+	c.policy.remove(key)
+	return true
+}
+
+func (c *Cache[K, V]) weigh(key K, value V) int64 {
+	if c.config.Weigher == nil {
+		return 1
+	}
+	return c.config.Weigher(key, value)
+}
+
+func (c *Cache[K, V]) lock() {
+	if c.config.ThreadSafe {
+		c.mu.Lock()
+	}
+}
+
+func (c *Cache[K, V]) unlock() {
+	if c.config.ThreadSafe {
+		c.mu.Unlock()
+	}
+}
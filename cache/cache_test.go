@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func sortedKeys[V any](c *Cache[string, V]) []string {
+	keys := c.Keys()
+	sort.Strings(keys)
+	return keys
+}
+
+func TestCache_LRU(t *testing.T) {
+	var evicted []string
+	c := New(Config[string, int]{
+		MaxEntries: 3,
+		OnEvict:    func(k string, _ int) { evicted = append(evicted, k) },
+	})
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+	c.Get("a")
+	c.Set("d", 4)
+
+	if !reflect.DeepEqual(evicted, []string{"b"}) {
+		t.Errorf("Expected b to be evicted, but got %v", evicted)
+	}
+	if result := sortedKeys(c); !reflect.DeepEqual(result, []string{"a", "c", "d"}) {
+		t.Errorf("Expected [a c d], but got %v", result)
+	}
+
+	// Peek does not refresh c, so it is evicted next.
+	c.Peek("c")
+	c.Set("e", 5)
+	if c.Contains("c") || !c.Contains("a") {
+		t.Errorf("Expected c to be evicted after a Peek")
+	}
+
+	// Overwriting keeps the entry count and does not evict.
+	c.Set("a", 10)
+	if v, _ := c.Get("a"); v != 10 || c.Len() != 3 || len(evicted) != 2 {
+		t.Errorf("Expected a=10 with 3 entries, but got %v with %v", v, c.Len())
+	}
+}
+
+func TestCache_Weight(t *testing.T) {
+	c := New(Config[string, string]{
+		Weigher:   func(_ string, v string) int64 { return int64(len(v)) },
+		MaxWeight: 10,
+	})
+	c.Set("a", "xxxx")
+	c.Set("b", "xxxx")
+	c.Set("c", "xxx")
+
+	if c.Contains("a") || c.Weight() != 7 {
+		t.Errorf("Expected a to make room, but got %v with weight %v", sortedKeys(c), c.Weight())
+	}
+	if c.Set("big", "xxxxxxxxxxx") {
+		t.Errorf("Expected an entry heavier than the cache to be rejected")
+	}
+
+	// Growing an entry evicts others, but never the entry itself.
+	c.Set("c", "xxxxxxxxx")
+	if result := sortedKeys(c); !reflect.DeepEqual(result, []string{"c"}) || c.Weight() != 9 {
+		t.Errorf("Expected only c with weight 9, but got %v with weight %v", result, c.Weight())
+	}
+
+	if c.Set("c", "xxxxxxxxxxxx") || c.Contains("c") {
+		t.Errorf("Expected an oversized update to remove the old value")
+	}
+}
+
+func TestCache_Stats(t *testing.T) {
+	c := New(Config[string, int]{MaxEntries: 1})
+	c.Set("a", 1)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+	c.Set("b", 2)
+
+	expected := Stats{Hits: 2, Misses: 1, Evictions: 1}
+	if s := c.Stats(); s != expected {
+		t.Errorf("Expected %v, but got %v", expected, s)
+	}
+	if r := c.Stats().HitRatio(); r < 0.66 || r > 0.67 {
+		t.Errorf("Expected hit ratio 2/3, but got %v", r)
+	}
+
+	c.Delete("b")
+	c.Clear()
+	if c.Stats().Evictions != 1 {
+		t.Errorf("Expected Delete and Clear not to count as evictions")
+	}
+	c.ResetStats()
+	if c.Stats() != (Stats{}) || c.Stats().HitRatio() != 0 {
+		t.Errorf("Expected zero stats after ResetStats")
+	}
+}
+
+func TestCache_ThreadSafe(t *testing.T) {
+	for _, p := range []Policy{LRU, LFU, ARC} {
+		var mu sync.Mutex
+		evictions := 0
+		c := New(Config[int, int]{
+			Policy:     p,
+			MaxEntries: 64,
+			ThreadSafe: true,
+			OnEvict: func(int, int) {
+				mu.Lock()
+				evictions++
+				mu.Unlock()
+			},
+		})
+
+		var wg sync.WaitGroup
+		for w := 0; w < 8; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for i := 0; i < 2000; i++ {
+					k := (w*31 + i*7) % 200
+					if _, ok := c.Get(k); !ok {
+						c.Set(k, i)
+					}
+				}
+			}(w)
+		}
+		wg.Wait()
+
+		s := c.Stats()
+		if c.Len() > 64 || s.Hits+s.Misses != 16000 || int(s.Evictions) != evictions {
+			t.Errorf("Policy %v: unexpected state, %v entries, stats %+v, %v callbacks", p, c.Len(), s, evictions)
+		}
+	}
+}
+
+func TestNew_InvalidCapacity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected New to panic without a positive limit")
+		}
+	}()
+	New(Config[string, int]{Weigher: func(string, int) int64 { return 1 }, MaxEntries: 10})
+}
@@ -0,0 +1,273 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+// Clock tells the current time. ExpiringMap reads time only through its
+// Clock, so tests can move time forward instead of sleeping.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock backed by time.Now.
+type SystemClock struct{}
+
+// Now returns the current local time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+type ExpiringConfig[K comparable, V any] struct {
+	// DefaultTTL is the lifetime Set gives to entries. Zero means they never
+	// expire.
+	DefaultTTL time.Duration
+	// Sliding makes every successful Get restart the lifetime of the entry.
+	Sliding bool
+	// CleanupInterval is how often a background janitor removes expired
+	// entries. Zero disables the janitor; expired entries are then removed
+	// when they are next looked up or by DeleteExpired.
+	CleanupInterval time.Duration
+	// OnExpire is called with every entry removed because it expired. It
+	// runs after the map lock is released.
+	OnExpire func(key K, value V)
+	// Clock defaults to SystemClock.
+	Clock Clock
+}
+
+type expiringEntry[K comparable, V any] struct {
+	key      K
+	value    V
+	ttl      time.Duration
+	deadline time.Time
+	item     *collections.PriorityQueueItem[*expiringEntry[K, V]]
+}
+
+type ExpiringMap[K comparable, V any] struct {
+	mu       sync.Mutex
+	config   ExpiringConfig[K, V]
+	entries  map[K]*expiringEntry[K, V]
+	queue    *collections.PriorityQueue[*expiringEntry[K, V]]
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewExpiringMap creates an empty map whose entries expire after a TTL, and
+// starts its janitor if CleanupInterval is set.
+//
+// Entries with a TTL are kept in a PriorityQueue ordered by deadline, so
+// finding the expired ones costs O(log n) each no matter how large the map
+// is. Call Stop when the map is no longer needed to end the janitor.
+// Returns a pointer to the new ExpiringMap.
+func NewExpiringMap[K comparable, V any](config ExpiringConfig[K, V]) *ExpiringMap[K, V] {
+	if config.Clock == nil {
+		config.Clock = SystemClock{}
+	}
+	m := &ExpiringMap[K, V]{
+		config:  config,
+		entries: make(map[K]*expiringEntry[K, V]),
+		queue: collections.NewPriorityQueue(func(a, b *expiringEntry[K, V]) bool {
+			return a.deadline.Before(b.deadline)
+		}),
+		stop: make(chan struct{}),
+	}
+	if config.CleanupInterval > 0 {
+		go m.janitor(config.CleanupInterval)
+	}
+	return m
+}
+
+// Set stores value for key with the default TTL.
+func (m *ExpiringMap[K, V]) Set(key K, value V) {
+	m.SetWithTTL(key, value, m.config.DefaultTTL)
+}
+
+// SetWithTTL stores value for key, expiring it after ttl. A ttl of zero or
+// less means the entry never expires.
+func (m *ExpiringMap[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		e = &expiringEntry[K, V]{key: key}
+		m.entries[key] = e
+	}
+	e.value, e.ttl = value, max(ttl, 0)
+	m.schedule(e, m.config.Clock.Now())
+}
+
+// Get returns the value for key and whether it is present and not expired.
+// An expired entry found this way is removed. With sliding expiration, a
+// hit restarts the lifetime of the entry.
+func (m *ExpiringMap[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	now := m.config.Clock.Now()
+	e, ok := m.entries[key]
+	if !ok {
+		m.mu.Unlock()
+		var zero V
+		return zero, false
+	}
+	if m.expired(e, now) {
+		m.remove(e)
+		m.mu.Unlock()
+		m.notify([]*expiringEntry[K, V]{e})
+		var zero V
+		return zero, false
+	}
+	if m.config.Sliding {
+		m.schedule(e, now)
+	}
+	value := e.value
+	m.mu.Unlock()
+	return value, true
+}
+
+// Touch restarts the lifetime of key.
+//
+// Returns false if key is absent or already expired.
+func (m *ExpiringMap[K, V]) Touch(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.config.Clock.Now()
+	e, ok := m.entries[key]
+	if !ok || m.expired(e, now) {
+		return false
+	}
+	m.schedule(e, now)
+	return true
+}
+
+// TTL returns how long key has left to live, and whether it is present and
+// not expired. An entry that never expires reports a TTL of zero.
+func (m *ExpiringMap[K, V]) TTL(key K) (time.Duration, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.config.Clock.Now()
+	e, ok := m.entries[key]
+	if !ok || m.expired(e, now) {
+		return 0, false
+	}
+	if e.item == nil {
+		return 0, true
+	}
+	return e.deadline.Sub(now), true
+}
+
+// Delete removes key without calling OnExpire.
+//
+// Returns false if key was absent.
+func (m *ExpiringMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if ok {
+		m.remove(e)
+	}
+	return ok
+}
+
+// DeleteExpired removes every expired entry and calls OnExpire for each.
+//
+// Returns the number of entries removed.
+func (m *ExpiringMap[K, V]) DeleteExpired() int {
+	m.mu.Lock()
+	now := m.config.Clock.Now()
+	var expired []*expiringEntry[K, V]
+	for !m.queue.IsEmpty() && m.expired(m.queue.Peek(), now) {
+		e := m.queue.Peek()
+		m.remove(e)
+		expired = append(expired, e)
+	}
+	m.mu.Unlock()
+	m.notify(expired)
+	return len(expired)
+}
+
+// Keys returns the keys that have not expired, in no particular order.
+func (m *ExpiringMap[K, V]) Keys() []K {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.config.Clock.Now()
+	keys := make([]K, 0, len(m.entries))
+	for k, e := range m.entries {
+		if !m.expired(e, now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Len returns the number of entries that have not expired.
+func (m *ExpiringMap[K, V]) Len() int {
+	return len(m.Keys())
+}
+
+// Clear removes every entry without calling OnExpire.
+func (m *ExpiringMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = make(map[K]*expiringEntry[K, V])
+	m.queue.Clear()
+}
+
+// Stop ends the janitor goroutine. It is safe to call Stop more than once
+// and the map stays usable afterwards.
+func (m *ExpiringMap[K, V]) Stop() {
+	m.stopOnce.Do(func() { close(m.stop) })
+}
+
+func (m *ExpiringMap[K, V]) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.DeleteExpired()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// schedule sets the deadline of e from now and its TTL, keeping the queue in
+// step.
+func (m *ExpiringMap[K, V]) schedule(e *expiringEntry[K, V], now time.Time) {
+	if e.ttl == 0 {
+		if e.item != nil {
+			m.queue.Remove(e.item)
+			e.item = nil
+		}
+		return
+	}
+	e.deadline = now.Add(e.ttl)
+	if e.item != nil {
+		m.queue.Update(e.item, e)
+	} else {
+		e.item = m.queue.Push(e)
+	}
+}
+
+func (m *ExpiringMap[K, V]) expired(e *expiringEntry[K, V], now time.Time) bool {
+	return e.item != nil && !now.Before(e.deadline)
+}
+
+func (m *ExpiringMap[K, V]) remove(e *expiringEntry[K, V]) {
+	delete(m.entries, e.key)
+	if e.item != nil {
+		m.queue.Remove(e.item)
+		e.item = nil
+	}
+}
+
+func (m *ExpiringMap[K, V]) notify(expired []*expiringEntry[K, V]) {
+	if m.config.OnExpire == nil {
+		return
+	}
+	for _, e := range expired {
+		m.config.OnExpire(e.key, e.value)
+	}
+}
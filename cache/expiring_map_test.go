@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestExpiringMap_TTL(t *testing.T) {
+	clock := newFakeClock()
+	var expired []string
+	m := NewExpiringMap(ExpiringConfig[string, int]{
+		DefaultTTL: time.Minute,
+		OnExpire:   func(k string, _ int) { expired = append(expired, k) },
+		Clock:      clock,
+	})
+	m.Set("a", 1)
+	m.SetWithTTL("b", 2, 10*time.Second)
+	m.SetWithTTL("forever", 3, 0)
+
+	clock.Advance(10 * time.Second)
+	if _, ok := m.Get("b"); ok {
+		t.Errorf("Expected b to expire at its deadline")
+	}
+	if !reflect.DeepEqual(expired, []string{"b"}) {
+		t.Errorf("Expected lazy expiry to call OnExpire, but got %v", expired)
+	}
+	if ttl, ok := m.TTL("a"); !ok || ttl != 50*time.Second {
+		t.Errorf("Expected 50s left, but got %v %v", ttl, ok)
+	}
+
+	clock.Advance(time.Hour)
+	if m.Len() != 1 {
+		t.Errorf("Expected only the entry without TTL to remain, but got %v", m.Len())
+	}
+	if n := m.DeleteExpired(); n != 1 || !reflect.DeepEqual(expired, []string{"b", "a"}) {
+		t.Errorf("Expected a to be swept, but got %v and %v", n, expired)
+	}
+	if v, ok := m.Get("forever"); !ok || v != 3 {
+		t.Errorf("Expected the entry without TTL to stay, but got %v %v", v, ok)
+	}
+	if ttl, ok := m.TTL("forever"); !ok || ttl != 0 {
+		t.Errorf("Expected zero TTL for an entry that never expires, but got %v", ttl)
+	}
+}
+
+func TestExpiringMap_Sliding(t *testing.T) {
+	clock := newFakeClock()
+	m := NewExpiringMap(ExpiringConfig[string, string]{
+		DefaultTTL: 30 * time.Second,
+		Sliding:    true,
+		Clock:      clock,
+	})
+	m.Set("session", "token")
+	m.Set("idle", "token")
+
+	for i := 0; i < 5; i++ {
+		clock.Advance(20 * time.Second)
+		if _, ok := m.Get("session"); !ok {
+			t.Fatalf("Expected reads to keep the session alive after %v steps", i+1)
+		}
+	}
+	if _, ok := m.Get("idle"); ok {
+		t.Errorf("Expected the idle entry to expire")
+	}
+
+	clock.Advance(20 * time.Second)
+	if !m.Touch("session") {
+		t.Errorf("Expected Touch to succeed")
+	}
+	clock.Advance(20 * time.Second)
+	if ttl, _ := m.TTL("session"); ttl != 10*time.Second {
+		t.Errorf("Expected Touch to restart the lifetime, but got %v left", ttl)
+	}
+}
+
+func TestExpiringMap_Overwrite(t *testing.T) {
+	clock := newFakeClock()
+	m := NewExpiringMap(ExpiringConfig[string, int]{Clock: clock})
+	m.SetWithTTL("a", 1, time.Second)
+	m.SetWithTTL("a", 2, time.Minute)
+	m.SetWithTTL("b", 1, time.Second)
+	m.Set("b", 2)
+
+	clock.Advance(2 * time.Second)
+	keys := m.Keys()
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, []string{"a", "b"}) || m.DeleteExpired() != 0 {
+		t.Errorf("Expected overwrites to replace the deadline, but got %v", keys)
+	}
+
+	m.Delete("a")
+	m.Clear()
+	if m.Len() != 0 {
+		t.Errorf("Expected an empty map after Clear")
+	}
+}
+
+func TestExpiringMap_Janitor(t *testing.T) {
+	clock := newFakeClock()
+	expired := make(chan string, 10)
+	m := NewExpiringMap(ExpiringConfig[string, int]{
+		DefaultTTL:      time.Minute,
+		CleanupInterval: time.Millisecond,
+		OnExpire:        func(k string, _ int) { expired <- k },
+		Clock:           clock,
+	})
+	defer m.Stop()
+	m.Set("a", 1)
+
+	clock.Advance(time.Minute)
+	select {
+	case k := <-expired:
+		if k != "a" {
+			t.Errorf("Expected a to expire, but got %v", k)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the janitor to remove the expired entry")
+	}
+
+	m.Stop()
+	m.Stop()
+}
@@ -0,0 +1,156 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kxrxh/goloom/result"
+	"github.com/kxrxh/goloom/retrier"
+)
+
+// Loader fetches the value for key from the backend.
+type Loader[K comparable, V any] func(ctx context.Context, key K) (V, error)
+
+type LoadingConfig struct {
+	// TTL is how long a loaded value is served. Zero means forever.
+	TTL time.Duration
+	// RefreshAhead starts a background reload when a value is read within
+	// this long of its expiry, so hot keys never miss. Zero disables it.
+	RefreshAhead time.Duration
+	// NegativeTTL is how long a failed load is served before the key is
+	// loaded again. Zero means failures are not cached.
+	NegativeTTL time.Duration
+	// Retry is used to call the loader. Nil means the loader is called once.
+	Retry *retrier.RetryConfig
+	// CleanupInterval and Clock are passed to the underlying ExpiringMap.
+	CleanupInterval time.Duration
+	Clock           Clock
+}
+
+// loadCall is one load in flight, shared by everyone waiting for the key.
+type loadCall[V any] struct {
+	done   chan struct{}
+	result result.Result[V]
+}
+
+type LoadingCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	config  LoadingConfig
+	entries *ExpiringMap[K, result.Result[V]]
+	calls   map[K]*loadCall[V]
+}
+
+// NewLoadingCache creates an empty cache that loads missing values on
+// demand.
+//
+// Returns a pointer to the new LoadingCache.
+func NewLoadingCache[K comparable, V any](config LoadingConfig) *LoadingCache[K, V] {
+	return &LoadingCache[K, V]{
+		config: config,
+		entries: NewExpiringMap(ExpiringConfig[K, result.Result[V]]{
+			CleanupInterval: config.CleanupInterval,
+			Clock:           config.Clock,
+		}),
+		calls: make(map[K]*loadCall[V]),
+	}
+}
+
+// GetOrLoad returns the cached result for key, calling loader if there is
+// none.
+//
+// Concurrent calls for the same key share a single load. The load keeps the
+// values of the context of the caller that started it but not its
+// cancellation, so one caller giving up does not fail the others; a caller
+// whose ctx is done stops waiting and gets ctx.Err() in the Result.
+// Returns the loaded value, or the loader error in the Result.
+func (c *LoadingCache[K, V]) GetOrLoad(ctx context.Context, key K, loader Loader[K, V]) result.Result[V] {
+	if r, ok := c.entries.Get(key); ok {
+		if c.config.RefreshAhead > 0 && r.IsSuccess() {
+			if ttl, _ := c.entries.TTL(key); ttl > 0 && ttl <= c.config.RefreshAhead {
+				c.load(ctx, key, loader, true)
+			}
+		}
+		return r
+	}
+
+	call := c.load(ctx, key, loader, false)
+	select {
+	case <-call.done:
+		return call.result
+	case <-ctx.Done():
+		return result.ErrResult[V](ctx.Err())
+	}
+}
+
+// Get returns the cached result for key without loading it.
+func (c *LoadingCache[K, V]) Get(key K) (result.Result[V], bool) {
+	return c.entries.Get(key)
+}
+
+// Set stores value for key as if it had been loaded.
+func (c *LoadingCache[K, V]) Set(key K, value V) {
+	c.entries.SetWithTTL(key, result.OkResult(value), c.config.TTL)
+}
+
+// Invalidate removes the cached result for key. A load already in flight
+// still stores its result when it finishes.
+func (c *LoadingCache[K, V]) Invalidate(key K) {
+	c.entries.Delete(key)
+}
+
+// Len returns the number of cached results, failures included.
+func (c *LoadingCache[K, V]) Len() int {
+	return c.entries.Len()
+}
+
+// Clear removes every cached result.
+func (c *LoadingCache[K, V]) Clear() {
+	c.entries.Clear()
+}
+
+// Stop ends the janitor of the underlying ExpiringMap.
+func (c *LoadingCache[K, V]) Stop() {
+	c.entries.Stop()
+}
+
+// load joins the load in flight for key or starts a new one.
+func (c *LoadingCache[K, V]) load(ctx context.Context, key K, loader Loader[K, V], refresh bool) *loadCall[V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if call, ok := c.calls[key]; ok {
+		return call
+	}
+	call := &loadCall[V]{done: make(chan struct{})}
+	c.calls[key] = call
+	go c.run(context.WithoutCancel(ctx), key, loader, refresh, call)
+	return call
+}
+
+func (c *LoadingCache[K, V]) run(ctx context.Context, key K, loader Loader[K, V], refresh bool, call *loadCall[V]) {
+	var value V
+	var err error
+	if c.config.Retry == nil || c.config.Retry.MaxRetries < 1 {
+		value, err = loader(ctx, key)
+	} else {
+		err = retrier.Retry(func() error {
+			value, err = loader(ctx, key)
+			return err
+		}, *c.config.Retry)
+	}
+	call.result = result.NewResult(value, err)
+
+	switch {
+	case err == nil:
+		c.entries.SetWithTTL(key, call.result, c.config.TTL)
+	case refresh:
+		// Keep serving the current value until it expires.
+	case c.config.NegativeTTL > 0:
+		c.entries.SetWithTTL(key, call.result, c.config.NegativeTTL)
+	}
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	c.mu.Unlock()
+	close(call.done)
+}
@@ -0,0 +1,151 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kxrxh/goloom/retrier"
+)
+
+func TestLoadingCache_Singleflight(t *testing.T) {
+	c := NewLoadingCache[string, int](LoadingConfig{TTL: time.Minute})
+	var calls atomic.Int32
+	release := make(chan struct{})
+	loader := func(ctx context.Context, key string) (int, error) {
+		calls.Add(1)
+		<-release
+		return len(key), nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 50)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = c.GetOrLoad(context.Background(), "hello", loader).Value
+		}(i)
+	}
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected a single load, but got %v", calls.Load())
+	}
+	for _, r := range results {
+		if r != 5 {
+			t.Fatalf("Expected every caller to get 5, but got %v", results)
+		}
+	}
+	if r := c.GetOrLoad(context.Background(), "hello", loader); r.Value != 5 || calls.Load() != 1 {
+		t.Errorf("Expected a cached hit, but got %v after %v loads", r.Value, calls.Load())
+	}
+}
+
+func TestLoadingCache_NegativeTTL(t *testing.T) {
+	clock := newFakeClock()
+	c := NewLoadingCache[string, int](LoadingConfig{TTL: time.Minute, NegativeTTL: 5 * time.Second, Clock: clock})
+	errBackend := errors.New("backend down")
+	var calls int
+	loader := func(context.Context, string) (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, errBackend
+		}
+		return 42, nil
+	}
+
+	if r := c.GetOrLoad(context.Background(), "k", loader); r.Err != errBackend {
+		t.Errorf("Expected the loader error, but got %v", r.Err)
+	}
+	if r := c.GetOrLoad(context.Background(), "k", loader); r.Err != errBackend || calls != 1 {
+		t.Errorf("Expected the cached failure, but got %v after %v calls", r.Err, calls)
+	}
+
+	clock.Advance(5 * time.Second)
+	if r := c.GetOrLoad(context.Background(), "k", loader); !r.IsSuccess() || r.Value != 42 {
+		t.Errorf("Expected a reload after the negative TTL, but got %v", r)
+	}
+}
+
+func TestLoadingCache_RefreshAhead(t *testing.T) {
+	clock := newFakeClock()
+	c := NewLoadingCache[string, int](LoadingConfig{TTL: time.Minute, RefreshAhead: 10 * time.Second, Clock: clock})
+	var version atomic.Int32
+	refreshed := make(chan struct{}, 1)
+	loader := func(context.Context, string) (int, error) {
+		v := version.Add(1)
+		if v > 1 {
+			refreshed <- struct{}{}
+		}
+		return int(v), nil
+	}
+
+	c.GetOrLoad(context.Background(), "k", loader)
+	clock.Advance(45 * time.Second)
+	if r := c.GetOrLoad(context.Background(), "k", loader); r.Value != 1 || version.Load() != 1 {
+		t.Errorf("Expected no refresh outside the window, but got %v", r.Value)
+	}
+
+	clock.Advance(10 * time.Second)
+	if r := c.GetOrLoad(context.Background(), "k", loader); r.Value != 1 {
+		t.Errorf("Expected the current value while refreshing, but got %v", r.Value)
+	}
+	select {
+	case <-refreshed:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected a background refresh")
+	}
+	for {
+		if r, _ := c.Get("k"); r.Value == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	clock.Advance(50 * time.Second)
+	if r, ok := c.Get("k"); !ok || r.Value != 2 {
+		t.Errorf("Expected the refreshed value to get a new TTL, but got %v %v", r.Value, ok)
+	}
+}
+
+func TestLoadingCache_Retry(t *testing.T) {
+	config := retrier.DefaultRetryConfig
+	config.Interval = time.Millisecond
+	c := NewLoadingCache[int, string](LoadingConfig{Retry: &config})
+
+	calls := 0
+	r := c.GetOrLoad(context.Background(), 1, func(context.Context, int) (string, error) {
+		calls++
+		if calls < 3 {
+			return "", errors.New("flaky")
+		}
+		return "ok", nil
+	})
+	if !r.IsSuccess() || r.Value != "ok" || calls != 3 {
+		t.Errorf("Expected success on the third attempt, but got %v after %v calls", r, calls)
+	}
+}
+
+func TestLoadingCache_ContextCanceled(t *testing.T) {
+	c := NewLoadingCache[string, int](LoadingConfig{})
+	release := make(chan struct{})
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := c.GetOrLoad(ctx, "k", func(ctx context.Context, _ string) (int, error) {
+		<-release
+		return 1, ctx.Err()
+	})
+	if r.Err != context.Canceled {
+		t.Errorf("Expected context.Canceled, but got %v", r.Err)
+	}
+}
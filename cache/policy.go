@@ -0,0 +1,280 @@
+package cache
+
+import "github.com/kxrxh/goloom/collections"
+
+// Policy selects which entry a Cache evicts when it is full.
+type Policy int
+
+const (
+	// LRU evicts the least recently used entry.
+	LRU Policy = iota
+	// LFU evicts the least frequently used entry, and the least recently
+	// used one among entries with the same frequency.
+	LFU
+	// ARC is the Adaptive Replacement Cache: it balances recency against
+	// frequency by remembering recently evicted keys and shifting space
+	// towards whichever of the two would have kept them.
+	ARC
+)
+
+// policy tracks the resident keys of a cache and picks eviction victims.
+// Every method runs in O(1), apart from skipping the protected key.
+type policy[K comparable] interface {
+	// hit records an access to a resident key.
+	hit(key K)
+	// miss is called before a new key is admitted.
+	miss(key K)
+	// insert makes key resident.
+	insert(key K)
+	// remove forgets a resident key without treating it as an eviction.
+	remove(key K)
+	// evict removes and returns the next victim other than skip.
+	evict(skip K) (K, bool)
+	clear()
+}
+
+func newPolicy[K comparable](p Policy) policy[K] {
+	switch p {
+	case LFU:
+		return newLFU[K]()
+	case ARC:
+		return newARC[K]()
+	default:
+		return &lruPolicy[K]{keys: newKeyList[K]()}
+	}
+}
+
+// keyList is a List of keys with O(1) lookup, most recent at the front.
+type keyList[K comparable] struct {
+	list *collections.List[K]
+	pos  map[K]*collections.ListElement[K]
+}
+
+func newKeyList[K comparable]() *keyList[K] {
+	return &keyList[K]{list: collections.NewList[K](), pos: make(map[K]*collections.ListElement[K])}
+}
+
+func (l *keyList[K]) len() int            { return l.list.Len() }
+func (l *keyList[K]) contains(key K) bool { _, ok := l.pos[key]; return ok }
+func (l *keyList[K]) pushFront(key K)     { l.pos[key] = l.list.PushFront(key) }
+func (l *keyList[K]) moveToFront(key K)   { l.list.MoveToFront(l.pos[key]) }
+func (l *keyList[K]) remove(key K) bool {
+	e, ok := l.pos[key]
+	if ok {
+		l.list.Remove(e)
+		delete(l.pos, key)
+	}
+	return ok
+}
+
+// oldest returns the least recent key other than skip.
+func (l *keyList[K]) oldest(skip K) (K, bool) {
+	for e := l.list.Back(); e != nil; e = e.Prev() {
+		if e.Value != skip {
+			return e.Value, true
+		}
+	}
+	var zero K
+	return zero, false
+}
+
+func (l *keyList[K]) clear() {
+	l.list.Clear()
+	l.pos = make(map[K]*collections.ListElement[K])
+}
+
+type lruPolicy[K comparable] struct {
+	keys *keyList[K]
+}
+
+func (p *lruPolicy[K]) hit(key K)    { p.keys.moveToFront(key) }
+func (p *lruPolicy[K]) miss(K)       {}
+func (p *lruPolicy[K]) insert(key K) { p.keys.pushFront(key) }
+func (p *lruPolicy[K]) remove(key K) { p.keys.remove(key) }
+func (p *lruPolicy[K]) clear()       { p.keys.clear() }
+
+func (p *lruPolicy[K]) evict(skip K) (K, bool) {
+	key, ok := p.keys.oldest(skip)
+	if ok {
+		p.keys.remove(key)
+	}
+	return key, ok
+}
+
+// lfuBucket holds the keys that have been used exactly freq times.
+type lfuBucket[K comparable] struct {
+	freq uint64
+	keys *keyList[K]
+}
+
+// lfuPolicy is the O(1) LFU scheme: a list of buckets in ascending
+// frequency, where a hit moves a key to the bucket right after its own.
+type lfuPolicy[K comparable] struct {
+	buckets *collections.List[*lfuBucket[K]]
+	bucket  map[K]*collections.ListElement[*lfuBucket[K]]
+}
+
+func newLFU[K comparable]() *lfuPolicy[K] {
+	return &lfuPolicy[K]{
+		buckets: collections.NewList[*lfuBucket[K]](),
+		bucket:  make(map[K]*collections.ListElement[*lfuBucket[K]]),
+	}
+}
+
+func (p *lfuPolicy[K]) hit(key K) {
+	cur := p.bucket[key]
+	next := cur.Next()
+	if next == nil || next.Value.freq != cur.Value.freq+1 {
+		next = p.buckets.InsertAfter(&lfuBucket[K]{freq: cur.Value.freq + 1, keys: newKeyList[K]()}, cur)
+	}
+	next.Value.keys.pushFront(key)
+	p.bucket[key] = next
+	p.unlink(cur, key)
+}
+
+func (p *lfuPolicy[K]) miss(K) {}
+
+func (p *lfuPolicy[K]) insert(key K) {
+	first := p.buckets.Front()
+	if first == nil || first.Value.freq != 1 {
+		first = p.buckets.PushFront(&lfuBucket[K]{freq: 1, keys: newKeyList[K]()})
+	}
+	first.Value.keys.pushFront(key)
+	p.bucket[key] = first
+}
+
+func (p *lfuPolicy[K]) remove(key K) {
+	if e, ok := p.bucket[key]; ok {
+		delete(p.bucket, key)
+		p.unlink(e, key)
+	}
+}
+
+func (p *lfuPolicy[K]) evict(skip K) (K, bool) {
+	for e := p.buckets.Front(); e != nil; e = e.Next() {
+		if key, ok := e.Value.keys.oldest(skip); ok {
+			p.remove(key)
+			return key, true
+		}
+	}
+	var zero K
+	return zero, false
+}
+
+func (p *lfuPolicy[K]) clear() {
+	p.buckets.Clear()
+	p.bucket = make(map[K]*collections.ListElement[*lfuBucket[K]])
+}
+
+// frequency returns how often a resident key has been used.
+func (p *lfuPolicy[K]) frequency(key K) uint64 {
+	if e, ok := p.bucket[key]; ok {
+		return e.Value.freq
+	}
+	return 0
+}
+
+// unlink removes key from the bucket in e, dropping the bucket once empty.
+func (p *lfuPolicy[K]) unlink(e *collections.ListElement[*lfuBucket[K]], key K) {
+	e.Value.keys.remove(key)
+	if e.Value.keys.len() == 0 {
+		p.buckets.Remove(e)
+	}
+}
+
+// arcPolicy implements ARC. t1 and t2 hold resident keys seen once and more
+// than once; b1 and b2 are ghost lists of keys recently evicted from them.
+// target is the share of resident entries ARC currently wants in t1.
+type arcPolicy[K comparable] struct {
+	t1, t2, b1, b2 *keyList[K]
+	target         int
+	// Set by miss for the key that is about to be inserted.
+	frequent bool
+	fromB2   bool
+}
+
+func newARC[K comparable]() *arcPolicy[K] {
+	return &arcPolicy[K]{t1: newKeyList[K](), t2: newKeyList[K](), b1: newKeyList[K](), b2: newKeyList[K]()}
+}
+
+func (p *arcPolicy[K]) hit(key K) {
+	if p.t1.remove(key) {
+		p.t2.pushFront(key)
+	} else {
+		p.t2.moveToFront(key)
+	}
+}
+
+func (p *arcPolicy[K]) miss(key K) {
+	p.frequent, p.fromB2 = false, false
+	resident := p.t1.len() + p.t2.len()
+	switch {
+	case p.b1.contains(key):
+		// Recency would have kept this key: grow t1's share.
+		p.target = min(p.target+max(p.b2.len()/p.b1.len(), 1), max(resident, 1))
+		p.b1.remove(key)
+		p.frequent = true
+	case p.b2.contains(key):
+		// Frequency would have kept this key: shrink t1's share.
+		p.target = max(p.target-max(p.b1.len()/p.b2.len(), 1), 0)
+		p.b2.remove(key)
+		p.frequent, p.fromB2 = true, true
+	}
+}
+
+func (p *arcPolicy[K]) insert(key K) {
+	if p.frequent {
+		p.t2.pushFront(key)
+	} else {
+		p.t1.pushFront(key)
+	}
+	p.frequent, p.fromB2 = false, false
+
+	// Keep the ghost lists no longer than the resident lists.
+	c := p.t1.len() + p.t2.len()
+	for p.b1.len() > 0 && p.t1.len()+p.b1.len() > c {
+		p.dropOldest(p.b1)
+	}
+	for p.b2.len() > 0 && c+p.b1.len()+p.b2.len() > 2*c {
+		p.dropOldest(p.b2)
+	}
+}
+
+func (p *arcPolicy[K]) remove(key K) {
+	if !p.t1.remove(key) {
+		p.t2.remove(key)
+	}
+}
+
+func (p *arcPolicy[K]) evict(skip K) (K, bool) {
+	fromT1 := p.t1.len() > 0 &&
+		(p.t1.len() > p.target || (p.fromB2 && p.t1.len() == p.target) || p.t2.len() == 0)
+	lists := [2][2]*keyList[K]{{p.t2, p.b2}, {p.t1, p.b1}}
+	if fromT1 {
+		lists[0], lists[1] = lists[1], lists[0]
+	}
+	for _, l := range lists {
+		if key, ok := l[0].oldest(skip); ok {
+			l[0].remove(key)
+			l[1].pushFront(key)
+			return key, true
+		}
+	}
+	var zero K
+	return zero, false
+}
+
+func (p *arcPolicy[K]) clear() {
+	p.t1.clear()
+	p.t2.clear()
+	p.b1.clear()
+	p.b2.clear()
+	p.target = 0
+	p.frequent, p.fromB2 = false, false
+}
+
+func (p *arcPolicy[K]) dropOldest(l *keyList[K]) {
+	if e := l.list.Back(); e != nil {
+		l.remove(e.Value)
+	}
+}
@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestCache_LFU(t *testing.T) {
+	c := New(Config[string, int]{Policy: LFU, MaxEntries: 3})
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+
+	// c has the lowest frequency, even though it was added last.
+	c.Set("d", 4)
+	if c.Contains("c") || !c.Contains("a") || !c.Contains("d") {
+		t.Errorf("Expected c to be evicted, but got %v", sortedKeys(c))
+	}
+
+	// d and the new entry share frequency 1; d is older.
+	c.Set("e", 5)
+	if c.Contains("d") {
+		t.Errorf("Expected d to be evicted, but got %v", sortedKeys(c))
+	}
+
+	lfu := c.policy.(*lfuPolicy[string])
+	if lfu.frequency("a") != 3 || lfu.frequency("e") != 1 || lfu.frequency("d") != 0 {
+		t.Errorf("Unexpected frequencies %v %v %v", lfu.frequency("a"), lfu.frequency("e"), lfu.frequency("d"))
+	}
+	var freqs []uint64
+	lfu.buckets.Each(func(b *lfuBucket[string]) bool {
+		freqs = append(freqs, b.freq)
+		return true
+	})
+	if !reflect.DeepEqual(freqs, []uint64{1, 2, 3}) {
+		t.Errorf("Expected buckets [1 2 3], but got %v", freqs)
+	}
+}
+
+func TestCache_ARCScanResistance(t *testing.T) {
+	// A small hot set is read twice per round while a long scan of one-off
+	// keys streams through. ARC keeps the hot set, LRU does not.
+	hitsFor := func(p Policy) uint64 {
+		c := New(Config[int, int]{Policy: p, MaxEntries: 50})
+		for round := 0; round < 20; round++ {
+			for pass := 0; pass < 2; pass++ {
+				for k := 0; k < 20; k++ {
+					if _, ok := c.Get(k); !ok {
+						c.Set(k, k)
+					}
+				}
+			}
+			for k := 0; k < 100; k++ {
+				scan := 1000 + round*100 + k
+				if _, ok := c.Get(scan); !ok {
+					c.Set(scan, scan)
+				}
+			}
+		}
+		return c.Stats().Hits
+	}
+
+	lru, arc := hitsFor(LRU), hitsFor(ARC)
+	if lru != 400 || arc < 700 {
+		t.Errorf("Expected ARC to keep the hot set, but got %v hits against %v for LRU", arc, lru)
+	}
+}
+
+func TestCache_ARCInvariants(t *testing.T) {
+	rng := rand.New(rand.NewSource(19))
+	c := New(Config[int, int]{Policy: ARC, MaxEntries: 32})
+	arc := c.policy.(*arcPolicy[int])
+
+	for i := 0; i < 20000; i++ {
+		k := rng.Intn(100)
+		if rng.Intn(10) == 0 {
+			k = 100 + rng.Intn(1000)
+		}
+		switch rng.Intn(20) {
+		case 0:
+			c.Delete(k)
+		default:
+			if _, ok := c.Get(k); !ok {
+				c.Set(k, k)
+			}
+		}
+
+		resident := arc.t1.len() + arc.t2.len()
+		if resident != c.Len() || c.Len() > 32 {
+			t.Fatalf("Resident lists hold %v keys, cache holds %v", resident, c.Len())
+		}
+		if arc.b1.len()+arc.b2.len() > 2*max(resident, 1) || arc.target < 0 || arc.target > 32 {
+			t.Fatalf("Ghost lists hold %v+%v keys with target %v", arc.b1.len(), arc.b2.len(), arc.target)
+		}
+	}
+}
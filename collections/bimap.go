@@ -0,0 +1,213 @@
+package collections
+
+import "errors"
+
+// ErrBiMapConflict is returned by BiMap.Put when the value is already mapped
+// to a different key and the map rejects conflicts.
+var ErrBiMapConflict = errors.New("collections: value is already mapped to another key")
+
+// ConflictPolicy decides what BiMap.Put does when the value it is given is
+// already mapped to a different key.
+type ConflictPolicy int
+
+const (
+	// RejectConflicts makes Put return ErrBiMapConflict and leave the map
+	// unchanged.
+	RejectConflicts ConflictPolicy = iota
+	// ReplaceConflicts makes Put remove the entry that holds the value
+	// before adding the new one.
+	ReplaceConflicts
+)
+
+type BiMap[K, V comparable] struct {
+	forward map[K]V
+	order   *List[K]
+	pos     map[K]*ListElement[K]
+	policy  ConflictPolicy
+	inverse *BiMap[V, K]
+}
+
+// NewBiMap creates an empty one-to-one map.
+//
+// policy decides how Put handles a value that is already mapped to another
+// key. Iteration order is unspecified.
+// Returns a pointer to the new BiMap.
+func NewBiMap[K, V comparable](policy ConflictPolicy) *BiMap[K, V] {
+	return newBiMap[K, V](policy, false)
+}
+
+// NewOrderedBiMap creates an empty one-to-one map that remembers insertion
+// order.
+//
+// Like OrderedMap, updating the value of an existing key keeps the key in
+// place, and deleting a key forgets its position. Each direction keeps its
+// own order: the inverse lists values in the order they were first put.
+// Returns a pointer to the new BiMap.
+func NewOrderedBiMap[K, V comparable](policy ConflictPolicy) *BiMap[K, V] {
+	return newBiMap[K, V](policy, true)
+}
+
+func newBiMap[K, V comparable](policy ConflictPolicy, ordered bool) *BiMap[K, V] {
+	m := &BiMap[K, V]{forward: make(map[K]V), policy: policy}
+	inv := &BiMap[V, K]{forward: make(map[V]K), policy: policy}
+	if ordered {
+		m.order, m.pos = NewList[K](), make(map[K]*ListElement[K])
+		inv.order, inv.pos = NewList[V](), make(map[V]*ListElement[V])
+	}
+	m.inverse, inv.inverse = inv, m
+	return m
+}
+
+// Put maps key to value.
+//
+// If key is already mapped, its old value is released. If value is already
+// mapped to another key, Put either returns ErrBiMapConflict or removes that
+// entry first, depending on the conflict policy.
+func (m *BiMap[K, V]) Put(key K, value V) error {
+	if old, ok := m.forward[key]; ok && old == value {
+		return nil
+	}
+	if other, ok := m.inverse.forward[value]; ok {
+		if m.policy == RejectConflicts {
+			return ErrBiMapConflict
+		}
+		m.DeleteByKey(other)
+	}
+	if old, ok := m.forward[key]; ok {
+		m.inverse.remove(old)
+	}
+	m.set(key, value)
+	m.inverse.set(value, key)
+	return nil
+}
+
+// GetByKey returns the value mapped to key and whether the key exists.
+func (m *BiMap[K, V]) GetByKey(key K) (V, bool) {
+	value, ok := m.forward[key]
+	return value, ok
+}
+
+// GetByValue returns the key mapped to value and whether the value exists.
+func (m *BiMap[K, V]) GetByValue(value V) (K, bool) {
+	key, ok := m.inverse.forward[value]
+	return key, ok
+}
+
+// ContainsKey checks if all the given keys are present.
+func (m *BiMap[K, V]) ContainsKey(keys ...K) bool {
+	for _, k := range keys {
+		if _, ok := m.forward[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsValue checks if all the given values are present.
+func (m *BiMap[K, V]) ContainsValue(values ...V) bool {
+	return m.inverse.ContainsKey(values...)
+}
+
+// DeleteByKey removes the entry for key.
+//
+// Returns the value that was mapped to key and true, or false if the key was
+// not present.
+func (m *BiMap[K, V]) DeleteByKey(key K) (V, bool) {
+	value, ok := m.forward[key]
+	if !ok {
+		return value, false
+	}
+	m.remove(key)
+	m.inverse.remove(value)
+	return value, true
+}
+
+// DeleteByValue removes the entry for value.
+//
+// Returns the key that was mapped to value and true, or false if the value
+// was not present.
+func (m *BiMap[K, V]) DeleteByValue(value V) (K, bool) {
+	return m.inverse.DeleteByKey(value)
+}
+
+// Inverse returns the value-to-key view of the map.
+//
+// The view is live: changes made through either map are visible in both.
+// Calling Inverse on the view returns the original map.
+func (m *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	return m.inverse
+}
+
+// Keys returns the keys of the map, in insertion order for an ordered map.
+func (m *BiMap[K, V]) Keys() []K {
+	if m.order != nil {
+		return m.order.ToSlice()
+	}
+	keys := make([]K, 0, len(m.forward))
+	for k := range m.forward {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Values returns the values of the map in the same order as Keys.
+func (m *BiMap[K, V]) Values() []V {
+	keys := m.Keys()
+	values := make([]V, len(keys))
+	for i, k := range keys {
+		values[i] = m.forward[k]
+	}
+	return values
+}
+
+// Each calls fn for every entry, in the same order as Keys, until fn returns
+// false.
+func (m *BiMap[K, V]) Each(fn func(key K, value V) bool) {
+	for _, k := range m.Keys() {
+		if !fn(k, m.forward[k]) {
+			return
+		}
+	}
+}
+
+// Len returns the number of entries in the map.
+func (m *BiMap[K, V]) Len() int {
+	return len(m.forward)
+}
+
+// IsEmpty checks if the map is empty.
+func (m *BiMap[K, V]) IsEmpty() bool {
+	return len(m.forward) == 0
+}
+
+// Clear removes all entries from the map and its inverse.
+func (m *BiMap[K, V]) Clear() {
+	m.clear()
+	m.inverse.clear()
+}
+
+// set stores one direction of an entry, keeping the position of an existing
+// key.
+func (m *BiMap[K, V]) set(key K, value V) {
+	if _, exists := m.forward[key]; !exists && m.order != nil {
+		m.pos[key] = m.order.PushBack(key)
+	}
+	m.forward[key] = value
+}
+
+// remove deletes one direction of an entry.
+func (m *BiMap[K, V]) remove(key K) {
+	delete(m.forward, key)
+	if m.order != nil {
+		m.order.Remove(m.pos[key])
+		delete(m.pos, key)
+	}
+}
+
+func (m *BiMap[K, V]) clear() {
+	m.forward = make(map[K]V)
+	if m.order != nil {
+		m.order.Clear()
+		m.pos = make(map[K]*ListElement[K])
+	}
+}
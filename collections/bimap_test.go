@@ -0,0 +1,134 @@
+package collections
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestBiMap_PutGet(t *testing.T) {
+	m := NewBiMap[string, int](RejectConflicts)
+	if err := m.Put("a", 1); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+	m.Put("b", 2)
+
+	if v, ok := m.GetByKey("a"); !ok || v != 1 {
+		t.Errorf("Expected 1, but got %v %v", v, ok)
+	}
+	if k, ok := m.GetByValue(2); !ok || k != "b" {
+		t.Errorf("Expected b, but got %v %v", k, ok)
+	}
+	if !m.ContainsKey("a", "b") || !m.ContainsValue(1, 2) || m.ContainsValue(3) {
+		t.Errorf("Contains returned an unexpected result")
+	}
+
+	// Re-mapping a key releases its old value.
+	m.Put("a", 3)
+	if m.ContainsValue(1) {
+		t.Errorf("Expected old value 1 to be released")
+	}
+	if m.Len() != 2 {
+		t.Errorf("Expected length 2, but got %v", m.Len())
+	}
+
+	keys := m.Keys()
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, []string{"a", "b"}) {
+		t.Errorf("Expected keys [a b], but got %v", keys)
+	}
+}
+
+func TestBiMap_RejectConflicts(t *testing.T) {
+	m := NewBiMap[string, int](RejectConflicts)
+	m.Put("a", 1)
+
+	if err := m.Put("b", 1); err != ErrBiMapConflict {
+		t.Errorf("Expected ErrBiMapConflict, but got %v", err)
+	}
+	if m.ContainsKey("b") || m.Len() != 1 {
+		t.Errorf("Expected map to be unchanged after a rejected put")
+	}
+	if err := m.Put("a", 1); err != nil {
+		t.Errorf("Expected putting the same entry again to succeed, but got %v", err)
+	}
+}
+
+func TestBiMap_ReplaceConflicts(t *testing.T) {
+	m := NewBiMap[string, int](ReplaceConflicts)
+	m.Put("a", 1)
+	m.Put("b", 2)
+
+	if err := m.Put("b", 1); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+	if m.ContainsKey("a") || m.ContainsValue(2) {
+		t.Errorf("Expected conflicting entries to be replaced")
+	}
+	if k, _ := m.GetByValue(1); k != "b" || m.Len() != 1 {
+		t.Errorf("Expected only b=1, but got %v with length %v", k, m.Len())
+	}
+}
+
+func TestBiMap_InverseIsLive(t *testing.T) {
+	m := NewBiMap[string, int](RejectConflicts)
+	inv := m.Inverse()
+
+	inv.Put(1, "one")
+	if v, ok := m.GetByKey("one"); !ok || v != 1 {
+		t.Errorf("Expected put through inverse to be visible, but got %v %v", v, ok)
+	}
+
+	m.Put("two", 2)
+	if k, ok := inv.GetByKey(2); !ok || k != "two" {
+		t.Errorf("Expected put through map to be visible in inverse, but got %v %v", k, ok)
+	}
+
+	if k, ok := m.DeleteByValue(1); !ok || k != "one" {
+		t.Errorf("Expected to delete one, but got %v %v", k, ok)
+	}
+	if inv.ContainsKey(1) || inv.Len() != 1 {
+		t.Errorf("Expected delete to be visible in inverse")
+	}
+	if inv.Inverse() != m {
+		t.Errorf("Expected inverse of inverse to be the original map")
+	}
+
+	inv.Clear()
+	if !m.IsEmpty() {
+		t.Errorf("Expected Clear through inverse to empty the map")
+	}
+}
+
+func TestBiMap_Ordered(t *testing.T) {
+	m := NewOrderedBiMap[string, int](ReplaceConflicts)
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 30)
+
+	if keys := m.Keys(); !reflect.DeepEqual(keys, []string{"c", "a", "b"}) {
+		t.Errorf("Expected updated key to keep its position, but got %v", keys)
+	}
+	if values := m.Values(); !reflect.DeepEqual(values, []int{30, 1, 2}) {
+		t.Errorf("Expected values in key order, but got %v", values)
+	}
+	if keys := m.Inverse().Keys(); !reflect.DeepEqual(keys, []int{1, 2, 30}) {
+		t.Errorf("Expected inverse in value insertion order, but got %v", keys)
+	}
+
+	m.DeleteByKey("a")
+	m.Put("a", 1)
+	if keys := m.Keys(); !reflect.DeepEqual(keys, []string{"c", "b", "a"}) {
+		t.Errorf("Expected re-added key at the end, but got %v", keys)
+	}
+
+	var seen []string
+	m.Each(func(k string, _ int) bool {
+		seen = append(seen, k)
+		return len(seen) < 2
+	})
+	if !reflect.DeepEqual(seen, []string{"c", "b"}) {
+		t.Errorf("Expected iteration to stop after two keys, but got %v", seen)
+	}
+}
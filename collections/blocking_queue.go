@@ -0,0 +1,290 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrQueueClosed is returned when putting into a closed BlockingQueue, or
+// taking from a closed BlockingQueue that has no items left.
+var ErrQueueClosed = errors.New("collections: queue is closed")
+
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	buf      []T
+	head     int
+	size     int
+	limit    int
+	closed   bool
+	notEmpty chan struct{}
+	notFull  chan struct{}
+}
+
+// NewBlockingQueue creates a new bounded queue that is safe for concurrent use.
+//
+// capacity is the maximum number of items the queue holds before Put blocks.
+// It panics if capacity is less than 1.
+// Returns a pointer to the new BlockingQueue.
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	if capacity < 1 {
+		panic("collections: BlockingQueue capacity must be positive")
+	}
+	return &BlockingQueue[T]{
+		buf:      make([]T, capacity),
+		limit:    capacity,
+		notEmpty: make(chan struct{}),
+		notFull:  make(chan struct{}),
+	}
+}
+
+// Put appends an item to the queue, blocking while the queue is full.
+//
+// Returns ErrQueueClosed if the queue is closed, or the context error if ctx
+// is done before there is room for the item.
+func (q *BlockingQueue[T]) Put(ctx context.Context, item T) error {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrQueueClosed
+		}
+		if q.size < q.limit {
+			q.push(item)
+			q.mu.Unlock()
+			return nil
+		}
+		wait := q.notFull
+		q.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Take removes and returns the oldest item, blocking while the queue is empty.
+//
+// After Close, Take keeps returning the remaining items and then
+// ErrQueueClosed. Returns the context error if ctx is done first.
+func (q *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		if q.size > 0 {
+			item := q.pop()
+			q.mu.Unlock()
+			return item, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			var zero T
+			return zero, ErrQueueClosed
+		}
+		wait := q.notEmpty
+		q.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// TryPut appends an item without blocking.
+//
+// Returns false if the queue is full or closed.
+func (q *BlockingQueue[T]) TryPut(item T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || q.size >= q.limit {
+		return false
+	}
+	q.push(item)
+	return true
+}
+
+// TryTake removes and returns the oldest item without blocking.
+//
+// Returns false if the queue is empty.
+func (q *BlockingQueue[T]) TryTake() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.pop(), true
+}
+
+// Peek returns the oldest item without removing it.
+//
+// Returns false if the queue is empty.
+func (q *BlockingQueue[T]) Peek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.buf[q.head], true
+}
+
+// DrainTo removes up to n items from the queue without blocking and returns
+// them oldest first. If n is not positive, all items are removed.
+func (q *BlockingQueue[T]) DrainTo(n int) []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if n <= 0 || n > q.size {
+		n = q.size
+	}
+	result := make([]T, n)
+	for i := range result {
+		result[i] = q.pop()
+	}
+	return result
+}
+
+// Resize changes the capacity of the queue.
+//
+// If the new capacity is smaller than the number of queued items, no items
+// are dropped; Put blocks until consumers bring the length under the new
+// capacity. It panics if capacity is less than 1.
+func (q *BlockingQueue[T]) Resize(capacity int) {
+	if capacity < 1 {
+		panic("collections: BlockingQueue capacity must be positive")
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	buf := make([]T, max(capacity, q.size))
+	for i := 0; i < q.size; i++ {
+		buf[i] = q.buf[(q.head+i)%len(q.buf)]
+	}
+	q.buf = buf
+	q.head = 0
+	q.limit = capacity
+	q.broadcast(&q.notFull)
+}
+
+// Close closes the queue. Further puts fail with ErrQueueClosed, while
+// consumers can still take the items that are left.
+//
+// Closing an already closed queue has no effect.
+func (q *BlockingQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.broadcast(&q.notEmpty)
+	q.broadcast(&q.notFull)
+}
+
+// IsClosed reports whether Close has been called.
+func (q *BlockingQueue[T]) IsClosed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.closed
+}
+
+// Len returns the number of items in the queue.
+func (q *BlockingQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// Cap returns the capacity of the queue.
+func (q *BlockingQueue[T]) Cap() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.limit
+}
+
+// IsEmpty checks if the queue is empty.
+//
+// Returns true if the queue has no items, otherwise false.
+func (q *BlockingQueue[T]) IsEmpty() bool {
+	return q.Len() == 0
+}
+
+// ToSlice returns a copy of the queued items, oldest first.
+//
+// It does not modify the queue.
+func (q *BlockingQueue[T]) ToSlice() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	result := make([]T, q.size)
+	for i := range result {
+		result[i] = q.buf[(q.head+i)%len(q.buf)]
+	}
+	return result
+}
+
+// Chan returns a channel that receives the items taken from the queue.
+//
+// The channel is closed once the queue is closed and drained, or when ctx is
+// done. An item that was already taken when ctx is done is dropped.
+func (q *BlockingQueue[T]) Chan(ctx context.Context) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for {
+			item, err := q.Take(ctx)
+			if err != nil {
+				return
+			}
+			select {
+			case ch <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// PutAll puts every item received from ch into the queue until ch is closed.
+//
+// Returns ErrQueueClosed or the context error if the items cannot all be put.
+func (q *BlockingQueue[T]) PutAll(ctx context.Context, ch <-chan T) error {
+	for {
+		select {
+		case item, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if err := q.Put(ctx, item); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (q *BlockingQueue[T]) push(item T) {
+	q.buf[(q.head+q.size)%len(q.buf)] = item
+	q.size++
+	q.broadcast(&q.notEmpty)
+}
+
+func (q *BlockingQueue[T]) pop() T {
+	var zero T
+	item := q.buf[q.head]
+	q.buf[q.head] = zero
+	q.head = (q.head + 1) % len(q.buf)
+	q.size--
+	q.broadcast(&q.notFull)
+	return item
+}
+
+// broadcast wakes every goroutine waiting on the signal channel.
+func (q *BlockingQueue[T]) broadcast(signal *chan struct{}) {
+	close(*signal)
+	*signal = make(chan struct{})
+}
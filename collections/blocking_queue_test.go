@@ -0,0 +1,198 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueue_PutTake(t *testing.T) {
+	q := NewBlockingQueue[int](3)
+	ctx := context.Background()
+
+	for i := 1; i <= 3; i++ {
+		if err := q.Put(ctx, i); err != nil {
+			t.Fatalf("Expected nil, but got %v", err)
+		}
+	}
+
+	if q.TryPut(4) {
+		t.Errorf("Expected TryPut on a full queue to fail")
+	}
+
+	expected := []int{1, 2, 3}
+	if result := q.ToSlice(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	for _, e := range expected {
+		v, err := q.Take(ctx)
+		if err != nil || v != e {
+			t.Errorf("Expected %v, but got %v (%v)", e, v, err)
+		}
+	}
+
+	if !q.IsEmpty() {
+		t.Errorf("Expected empty queue")
+	}
+	if _, ok := q.TryTake(); ok {
+		t.Errorf("Expected TryTake on an empty queue to fail")
+	}
+}
+
+func TestBlockingQueue_Backpressure(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	q.TryPut(1)
+
+	done := make(chan error)
+	go func() {
+		done <- q.Put(context.Background(), 2)
+	}()
+
+	select {
+	case <-done:
+		t.Fatalf("Expected Put to block on a full queue")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if v, _ := q.TryTake(); v != 1 {
+		t.Errorf("Expected 1, but got %v", v)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+	if v, _ := q.TryTake(); v != 2 {
+		t.Errorf("Expected 2, but got %v", v)
+	}
+}
+
+func TestBlockingQueue_ContextCancel(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := q.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, but got %v", err)
+	}
+
+	q.TryPut(1)
+	if err := q.Put(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, but got %v", err)
+	}
+}
+
+func TestBlockingQueue_Close(t *testing.T) {
+	q := NewBlockingQueue[string](4)
+	ctx := context.Background()
+	q.TryPut("a")
+	q.TryPut("b")
+	q.Close()
+
+	if err := q.Put(ctx, "c"); err != ErrQueueClosed {
+		t.Errorf("Expected ErrQueueClosed, but got %v", err)
+	}
+
+	for _, e := range []string{"a", "b"} {
+		if v, err := q.Take(ctx); err != nil || v != e {
+			t.Errorf("Expected %v, but got %v (%v)", e, v, err)
+		}
+	}
+	if _, err := q.Take(ctx); err != ErrQueueClosed {
+		t.Errorf("Expected ErrQueueClosed, but got %v", err)
+	}
+}
+
+func TestBlockingQueue_CloseWakesConsumers(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := q.Take(context.Background()); err != ErrQueueClosed {
+				t.Errorf("Expected ErrQueueClosed, but got %v", err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	wg.Wait()
+}
+
+func TestBlockingQueue_DrainTo(t *testing.T) {
+	q := NewBlockingQueue[int](5)
+	for i := 1; i <= 5; i++ {
+		q.TryPut(i)
+	}
+
+	expected := []int{1, 2}
+	if result := q.DrainTo(2); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	expected = []int{3, 4, 5}
+	if result := q.DrainTo(0); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestBlockingQueue_Resize(t *testing.T) {
+	q := NewBlockingQueue[int](4)
+	for i := 1; i <= 4; i++ {
+		q.TryPut(i)
+	}
+	q.TryTake()
+
+	q.Resize(2)
+	if q.Cap() != 2 || q.Len() != 3 {
+		t.Errorf("Expected cap 2 and len 3, but got %v and %v", q.Cap(), q.Len())
+	}
+	q.TryTake()
+	if q.TryPut(5) {
+		t.Errorf("Expected TryPut to fail while over capacity")
+	}
+
+	q.Resize(6)
+	for i := 5; i <= 8; i++ {
+		if !q.TryPut(i) {
+			t.Errorf("Expected TryPut(%v) to succeed", i)
+		}
+	}
+
+	expected := []int{3, 4, 5, 6, 7, 8}
+	if result := q.ToSlice(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestBlockingQueue_Channels(t *testing.T) {
+	q := NewBlockingQueue[int](2)
+	ctx := context.Background()
+
+	in := make(chan int)
+	go func() {
+		for i := 0; i < 10; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+	go func() {
+		if err := q.PutAll(ctx, in); err != nil {
+			t.Errorf("Expected nil, but got %v", err)
+		}
+		q.Close()
+	}()
+
+	var result []int
+	for v := range q.Chan(ctx) {
+		result = append(result, v)
+	}
+
+	expected := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
@@ -109,3 +109,14 @@ func (c *Counter) Clear() {
 func (c Counter) Get(elem interface{}) uint64 {
 	return c.elements[elem]
 }
+
+// Set sets the count for the specified element.
+//
+// A count of 0 removes the element from the Counter.
+func (c *Counter) Set(elem interface{}, count uint64) {
+	if count == 0 {
+		delete(c.elements, elem)
+		return
+	}
+	c.elements[elem] = count
+}
@@ -0,0 +1,171 @@
+package collections
+
+type DisjointSet[T comparable] struct {
+	index    map[T]int
+	elements []T
+	parent   []int
+	rank     []uint8
+	size     []int
+	sets     int
+}
+
+// NewDisjointSet creates a disjoint-set forest where each of the given
+// elements starts in a group of its own.
+//
+// Returns a pointer to the new DisjointSet.
+func NewDisjointSet[T comparable](elems ...T) *DisjointSet[T] {
+	d := &DisjointSet[T]{index: make(map[T]int, len(elems))}
+	d.Add(elems...)
+	return d
+}
+
+// Add adds each element that is not present yet as a group of its own.
+func (d *DisjointSet[T]) Add(elems ...T) {
+	for _, e := range elems {
+		if _, ok := d.index[e]; ok {
+			continue
+		}
+		i := len(d.elements)
+		d.index[e] = i
+		d.elements = append(d.elements, e)
+		d.parent = append(d.parent, i)
+		d.rank = append(d.rank, 0)
+		d.size = append(d.size, 1)
+		d.sets++
+	}
+}
+
+// Contains checks if all the given elements are present.
+func (d *DisjointSet[T]) Contains(elems ...T) bool {
+	for _, e := range elems {
+		if _, ok := d.index[e]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Union merges the groups of a and b, adding either element if it is not
+// present yet.
+//
+// Returns false if a and b were already in the same group.
+func (d *DisjointSet[T]) Union(a, b T) bool {
+	d.Add(a, b)
+	ra, rb := d.root(d.index[a]), d.root(d.index[b])
+	if ra == rb {
+		return false
+	}
+	if d.rank[ra] < d.rank[rb] {
+		ra, rb = rb, ra
+	}
+	d.parent[rb] = ra
+	d.size[ra] += d.size[rb]
+	if d.rank[ra] == d.rank[rb] {
+		d.rank[ra]++
+	}
+	d.sets--
+	return true
+}
+
+// Find returns the representative of the group of x and whether x is
+// present. Two elements are in the same group exactly when they have the
+// same representative.
+func (d *DisjointSet[T]) Find(x T) (T, bool) {
+	i, ok := d.index[x]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return d.elements[d.root(i)], true
+}
+
+// Connected checks if a and b are present and in the same group.
+func (d *DisjointSet[T]) Connected(a, b T) bool {
+	ia, okA := d.index[a]
+	ib, okB := d.index[b]
+	return okA && okB && d.root(ia) == d.root(ib)
+}
+
+// SetCount returns the number of groups.
+func (d *DisjointSet[T]) SetCount() int {
+	return d.sets
+}
+
+// SetSize returns the number of elements in the group of x, or 0 if x is not
+// present.
+func (d *DisjointSet[T]) SetSize(x T) int {
+	i, ok := d.index[x]
+	if !ok {
+		return 0
+	}
+	return d.size[d.root(i)]
+}
+
+// Group returns the elements in the group of x, or an empty Set if x is not
+// present.
+func (d *DisjointSet[T]) Group(x T) Set[T] {
+	group := NewSet[T]()
+	i, ok := d.index[x]
+	if !ok {
+		return group
+	}
+	r := d.root(i)
+	for j, e := range d.elements {
+		if d.root(j) == r {
+			group.Add(e)
+		}
+	}
+	return group
+}
+
+// Groups returns every group as a Set, ordered by the element of each group
+// that was added first.
+func (d *DisjointSet[T]) Groups() []Set[T] {
+	groups := make([]Set[T], 0, d.sets)
+	byRoot := make(map[int]int, d.sets)
+	for i, e := range d.elements {
+		r := d.root(i)
+		g, ok := byRoot[r]
+		if !ok {
+			g = len(groups)
+			byRoot[r] = g
+			groups = append(groups, NewSetOfSize[T](uint64(d.size[r])))
+		}
+		groups[g].Add(e)
+	}
+	return groups
+}
+
+// ToSlice returns the elements in the order they were added.
+func (d *DisjointSet[T]) ToSlice() []T {
+	return append([]T{}, d.elements...)
+}
+
+// Len returns the number of elements.
+func (d *DisjointSet[T]) Len() int {
+	return len(d.elements)
+}
+
+// IsEmpty checks if the disjoint set has no elements.
+func (d *DisjointSet[T]) IsEmpty() bool {
+	return len(d.elements) == 0
+}
+
+// Clear removes all elements.
+func (d *DisjointSet[T]) Clear() {
+	d.index = make(map[T]int)
+	d.elements, d.parent, d.rank, d.size = nil, nil, nil, nil
+	d.sets = 0
+}
+
+// root returns the root of i, pointing every node on the way directly at it.
+func (d *DisjointSet[T]) root(i int) int {
+	r := i
+	for d.parent[r] != r {
+		r = d.parent[r]
+	}
+	for d.parent[i] != r {
+		d.parent[i], i = r, d.parent[i]
+	}
+	return r
+}
@@ -0,0 +1,97 @@
+package collections
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDisjointSet_Union(t *testing.T) {
+	d := NewDisjointSet(1, 2, 3, 4, 5)
+	if d.SetCount() != 5 {
+		t.Errorf("Expected 5 sets, but got %v", d.SetCount())
+	}
+
+	if !d.Union(1, 2) || !d.Union(3, 4) || !d.Union(2, 4) {
+		t.Errorf("Expected unions of separate groups to succeed")
+	}
+	if d.Union(1, 3) {
+		t.Errorf("Expected union within a group to return false")
+	}
+
+	if !d.Connected(1, 4) || d.Connected(1, 5) || d.Connected(1, 6) {
+		t.Errorf("Connected returned an unexpected result")
+	}
+	if d.SetCount() != 2 || d.SetSize(3) != 4 || d.SetSize(5) != 1 || d.SetSize(6) != 0 {
+		t.Errorf("Unexpected counts: %v sets, sizes %v %v", d.SetCount(), d.SetSize(3), d.SetSize(5))
+	}
+
+	r1, _ := d.Find(1)
+	r4, _ := d.Find(4)
+	if r1 != r4 {
+		t.Errorf("Expected the same representative, but got %v and %v", r1, r4)
+	}
+	if _, ok := d.Find(6); ok {
+		t.Errorf("Expected Find to fail for a missing element")
+	}
+}
+
+func TestDisjointSet_Groups(t *testing.T) {
+	// Accounts that share an email belong to the same person.
+	d := NewDisjointSet[string]()
+	d.Union("alice@a", "alice@b")
+	d.Union("bob@a", "bob@b")
+	d.Union("alice@b", "alice@c")
+	d.Add("carol@a")
+
+	groups := d.Groups()
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, but got %v", len(groups))
+	}
+	if !groups[0].Equals(NewSet("alice@a", "alice@b", "alice@c")) {
+		t.Errorf("Unexpected first group %v", groups[0].ToSlice())
+	}
+	if !groups[1].Equals(NewSet("bob@a", "bob@b")) || !groups[2].Equals(NewSet("carol@a")) {
+		t.Errorf("Unexpected groups %v %v", groups[1].ToSlice(), groups[2].ToSlice())
+	}
+	if !d.Group("bob@b").Equals(groups[1]) || !d.Group("dave@a").IsEmpty() {
+		t.Errorf("Group returned an unexpected result")
+	}
+
+	d.Clear()
+	if !d.IsEmpty() || d.SetCount() != 0 {
+		t.Errorf("Expected empty disjoint set after Clear")
+	}
+}
+
+func TestDisjointSet_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	d := NewDisjointSet[int]()
+	label := make([]int, 500)
+	for i := range label {
+		label[i] = i
+		d.Add(i)
+	}
+
+	for k := 0; k < 400; k++ {
+		a, b := rng.Intn(500), rng.Intn(500)
+		d.Union(a, b)
+		from, to := label[b], label[a]
+		for i := range label {
+			if label[i] == from {
+				label[i] = to
+			}
+		}
+	}
+
+	labels := NewSet[int]()
+	for i := range label {
+		labels.Add(label[i])
+		j := rng.Intn(500)
+		if d.Connected(i, j) != (label[i] == label[j]) {
+			t.Fatalf("Connected(%v, %v) disagrees with the reference", i, j)
+		}
+	}
+	if d.SetCount() != labels.Len() {
+		t.Errorf("Expected %v sets, but got %v", labels.Len(), d.SetCount())
+	}
+}
@@ -0,0 +1,95 @@
+package collections
+
+// Number is satisfied by every integer and floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+type FenwickTree[T Number] struct {
+	values []T
+	tree   []T
+}
+
+// NewFenwickTree creates a Fenwick tree of n zero values.
+//
+// Returns a pointer to the new FenwickTree.
+func NewFenwickTree[T Number](n int) *FenwickTree[T] {
+	return &FenwickTree[T]{values: make([]T, n), tree: make([]T, n+1)}
+}
+
+// FenwickTreeFromSlice creates a Fenwick tree holding a copy of values in
+// O(n).
+//
+// Returns a pointer to the new FenwickTree.
+func FenwickTreeFromSlice[T Number](values []T) *FenwickTree[T] {
+	f := &FenwickTree[T]{values: append([]T{}, values...), tree: make([]T, len(values)+1)}
+	for i, v := range values {
+		j := i + 1
+		f.tree[j] += v
+		if parent := j + j&-j; parent < len(f.tree) {
+			f.tree[parent] += f.tree[j]
+		}
+	}
+	return f
+}
+
+// Add adds delta to the value at index i in O(log n).
+func (f *FenwickTree[T]) Add(i int, delta T) {
+	f.values[i] += delta
+	for j := i + 1; j < len(f.tree); j += j & -j {
+		f.tree[j] += delta
+	}
+}
+
+// Set replaces the value at index i in O(log n).
+func (f *FenwickTree[T]) Set(i int, value T) {
+	f.Add(i, value-f.values[i])
+}
+
+// Get returns the value at index i.
+func (f *FenwickTree[T]) Get(i int) T {
+	return f.values[i]
+}
+
+// PrefixSum returns the sum of the first n values in O(log n).
+func (f *FenwickTree[T]) PrefixSum(n int) T {
+	if n < 0 || n > len(f.values) {
+		panic("collections: FenwickTree prefix out of range")
+	}
+	var sum T
+	for j := n; j > 0; j -= j & -j {
+		sum += f.tree[j]
+	}
+	return sum
+}
+
+// RangeSum returns the sum of the values at indexes in [from, to).
+func (f *FenwickTree[T]) RangeSum(from, to int) T {
+	if from > to {
+		panic("collections: FenwickTree range out of order")
+	}
+	return f.PrefixSum(to) - f.PrefixSum(from)
+}
+
+// Sum returns the sum of all values.
+func (f *FenwickTree[T]) Sum() T {
+	return f.PrefixSum(len(f.values))
+}
+
+// Len returns the number of values.
+func (f *FenwickTree[T]) Len() int {
+	return len(f.values)
+}
+
+// ToSlice returns a copy of the values.
+func (f *FenwickTree[T]) ToSlice() []T {
+	return append([]T{}, f.values...)
+}
+
+// Clear resets every value to zero, keeping the length.
+func (f *FenwickTree[T]) Clear() {
+	clear(f.values)
+	clear(f.tree)
+}
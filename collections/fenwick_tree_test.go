@@ -0,0 +1,58 @@
+package collections
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestFenwickTree_Sums(t *testing.T) {
+	f := FenwickTreeFromSlice([]int{3, 2, -1, 6, 5, 4, -3, 3, 7, 2, 3})
+
+	if result := f.PrefixSum(5); result != 15 {
+		t.Errorf("Expected 15, but got %v", result)
+	}
+	if result := f.RangeSum(2, 7); result != 11 {
+		t.Errorf("Expected 11, but got %v", result)
+	}
+	if f.PrefixSum(0) != 0 || f.Sum() != 31 {
+		t.Errorf("Expected empty prefix 0 and total 31, but got %v and %v", f.PrefixSum(0), f.Sum())
+	}
+
+	f.Add(3, 10)
+	f.Set(0, 0)
+	if f.Get(3) != 16 || f.Get(0) != 0 || f.RangeSum(0, 4) != 17 {
+		t.Errorf("Unexpected values after updates: %v", f.ToSlice())
+	}
+}
+
+func TestFenwickTree_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	f := NewFenwickTree[float64](200)
+	reference := make([]float64, 200)
+
+	for k := 0; k < 2000; k++ {
+		i := rng.Intn(200)
+		delta := float64(rng.Intn(100) - 50)
+		f.Add(i, delta)
+		reference[i] += delta
+
+		from := rng.Intn(201)
+		to := from + rng.Intn(201-from)
+		expected := 0.0
+		for _, v := range reference[from:to] {
+			expected += v
+		}
+		if result := f.RangeSum(from, to); result != expected {
+			t.Fatalf("RangeSum(%v, %v): expected %v, but got %v", from, to, expected, result)
+		}
+	}
+	if !reflect.DeepEqual(f.ToSlice(), reference) {
+		t.Errorf("Expected values to match the reference")
+	}
+
+	f.Clear()
+	if f.Sum() != 0 || f.Len() != 200 {
+		t.Errorf("Expected zeroed tree of the same length after Clear")
+	}
+}
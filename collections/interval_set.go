@@ -0,0 +1,182 @@
+package collections
+
+import (
+	"cmp"
+	"sort"
+)
+
+type IntervalSet[K cmp.Ordered] struct {
+	intervals []Interval[K]
+}
+
+// NewIntervalSet creates a new interval set covering the union of the given
+// intervals.
+//
+// The set is kept normalized: a sorted list of disjoint, non-adjacent,
+// non-empty half-open intervals.
+// Returns a new IntervalSet.
+func NewIntervalSet[K cmp.Ordered](intervals ...Interval[K]) IntervalSet[K] {
+	s := IntervalSet[K]{intervals: make([]Interval[K], 0, len(intervals))}
+	s.Add(intervals...)
+	return s
+}
+
+// Add adds the given intervals to the set, merging them with any intervals
+// they overlap or touch.
+func (s *IntervalSet[K]) Add(intervals ...Interval[K]) {
+	for _, iv := range intervals {
+		if iv.IsEmpty() {
+			continue
+		}
+		// First interval that ends at or after iv starts.
+		i := sort.Search(len(s.intervals), func(i int) bool {
+			return s.intervals[i].High >= iv.Low
+		})
+		j := i
+		for j < len(s.intervals) && s.intervals[j].Low <= iv.High {
+			iv.Low = min(iv.Low, s.intervals[j].Low)
+			iv.High = max(iv.High, s.intervals[j].High)
+			j++
+		}
+		s.replace(i, j, iv)
+	}
+}
+
+// Remove subtracts the given intervals from the set.
+func (s *IntervalSet[K]) Remove(intervals ...Interval[K]) {
+	for _, iv := range intervals {
+		if iv.IsEmpty() {
+			continue
+		}
+		// First interval that ends after iv starts.
+		i := sort.Search(len(s.intervals), func(i int) bool {
+			return s.intervals[i].High > iv.Low
+		})
+		j := i
+		var keep []Interval[K]
+		for j < len(s.intervals) && s.intervals[j].Low < iv.High {
+			cur := s.intervals[j]
+			if cur.Low < iv.Low {
+				keep = append(keep, Interval[K]{Low: cur.Low, High: iv.Low})
+			}
+			if iv.High < cur.High {
+				keep = append(keep, Interval[K]{Low: iv.High, High: cur.High})
+			}
+			j++
+		}
+		s.replace(i, j, keep...)
+	}
+}
+
+// Contains checks if all the given points are covered by the set.
+func (s IntervalSet[K]) Contains(points ...K) bool {
+	for _, p := range points {
+		i := sort.Search(len(s.intervals), func(i int) bool {
+			return s.intervals[i].High > p
+		})
+		if i == len(s.intervals) || !s.intervals[i].Contains(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsInterval checks if iv is entirely covered by the set. An empty
+// interval is always covered.
+func (s IntervalSet[K]) ContainsInterval(iv Interval[K]) bool {
+	if iv.IsEmpty() {
+		return true
+	}
+	i := sort.Search(len(s.intervals), func(i int) bool {
+		return s.intervals[i].High > iv.Low
+	})
+	return i < len(s.intervals) && s.intervals[i].Low <= iv.Low && iv.High <= s.intervals[i].High
+}
+
+// Overlaps checks if any point of iv is covered by the set.
+func (s IntervalSet[K]) Overlaps(iv Interval[K]) bool {
+	i := sort.Search(len(s.intervals), func(i int) bool {
+		return s.intervals[i].High > iv.Low
+	})
+	return i < len(s.intervals) && s.intervals[i].Overlaps(iv)
+}
+
+// ToSlice returns the normalized intervals of the set in ascending order.
+func (s IntervalSet[K]) ToSlice() []Interval[K] {
+	return append([]Interval[K]{}, s.intervals...)
+}
+
+// Len returns the number of disjoint intervals in the set.
+func (s IntervalSet[K]) Len() int {
+	return len(s.intervals)
+}
+
+// IsEmpty checks if the set covers no points.
+func (s IntervalSet[K]) IsEmpty() bool {
+	return len(s.intervals) == 0
+}
+
+// Copy creates a new IntervalSet as a copy of the current one.
+func (s IntervalSet[K]) Copy() IntervalSet[K] {
+	return IntervalSet[K]{intervals: s.ToSlice()}
+}
+
+// Equals checks if two sets cover the same points.
+func (s IntervalSet[K]) Equals(other IntervalSet[K]) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	for i, iv := range s.intervals {
+		if iv != other.intervals[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a new set covering the points of both sets.
+func (s IntervalSet[K]) Union(other IntervalSet[K]) IntervalSet[K] {
+	cp := s.Copy()
+	cp.Add(other.intervals...)
+	return cp
+}
+
+// Intersection returns a new set covering the points present in both sets.
+func (s IntervalSet[K]) Intersection(other IntervalSet[K]) IntervalSet[K] {
+	result := IntervalSet[K]{intervals: make([]Interval[K], 0)}
+	i, j := 0, 0
+	for i < len(s.intervals) && j < len(other.intervals) {
+		a, b := s.intervals[i], other.intervals[j]
+		if low, high := max(a.Low, b.Low), min(a.High, b.High); low < high {
+			result.intervals = append(result.intervals, Interval[K]{Low: low, High: high})
+		}
+		if a.High < b.High {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// Difference returns a new set covering the points of the receiver that are
+// not in other.
+func (s IntervalSet[K]) Difference(other IntervalSet[K]) IntervalSet[K] {
+	cp := s.Copy()
+	cp.Remove(other.intervals...)
+	return cp
+}
+
+// Clear removes all intervals from the set.
+func (s *IntervalSet[K]) Clear() {
+	s.intervals = make([]Interval[K], 0)
+}
+
+// replace substitutes s.intervals[i:j] with the given intervals. The result
+// is built in a new slice because value copies of the set share the old one.
+func (s *IntervalSet[K]) replace(i, j int, with ...Interval[K]) {
+	intervals := make([]Interval[K], 0, len(s.intervals)-(j-i)+len(with))
+	intervals = append(intervals, s.intervals[:i]...)
+	intervals = append(intervals, with...)
+	s.intervals = append(intervals, s.intervals[j:]...)
+}
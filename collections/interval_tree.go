@@ -0,0 +1,354 @@
+package collections
+
+import "cmp"
+
+// Interval is the half-open range [Low, High).
+//
+// Half-open bounds let adjacent ranges such as [1, 5) and [5, 9) meet without
+// overlapping, and make unions and differences of ranges exact. An interval
+// with Low >= High is empty.
+type Interval[K cmp.Ordered] struct {
+	Low  K
+	High K
+}
+
+// IsEmpty checks if the interval contains no points.
+func (iv Interval[K]) IsEmpty() bool {
+	return !(iv.Low < iv.High)
+}
+
+// Contains checks if p lies within the interval.
+func (iv Interval[K]) Contains(p K) bool {
+	return iv.Low <= p && p < iv.High
+}
+
+// Overlaps checks if the interval shares at least one point with other.
+func (iv Interval[K]) Overlaps(other Interval[K]) bool {
+	return iv.Low < other.High && other.Low < iv.High && !iv.IsEmpty() && !other.IsEmpty()
+}
+
+// Touches checks if the interval overlaps or is adjacent to other, that is,
+// if their union is a single interval.
+func (iv Interval[K]) Touches(other Interval[K]) bool {
+	return iv.Low <= other.High && other.Low <= iv.High
+}
+
+// IntervalEntry is an interval stored in an IntervalTree with its value.
+type IntervalEntry[K cmp.Ordered, V any] struct {
+	Interval Interval[K]
+	Value    V
+}
+
+type intervalNode[K cmp.Ordered, V any] struct {
+	iv          Interval[K]
+	value       V
+	maxHigh     K
+	height      int
+	left, right *intervalNode[K, V]
+}
+
+type IntervalTree[K cmp.Ordered, V any] struct {
+	root *intervalNode[K, V]
+	size int
+}
+
+// NewIntervalTree creates an empty interval tree.
+//
+// The tree is a balanced binary search tree ordered by Low, then High, and
+// augmented with the maximum High of every subtree, so overlap and stabbing
+// queries run in O(log n + m) for m results.
+// Returns a pointer to the new IntervalTree.
+func NewIntervalTree[K cmp.Ordered, V any]() *IntervalTree[K, V] {
+	return &IntervalTree[K, V]{}
+}
+
+// Insert adds iv with the given value. Inserting an interval that is already
+// stored replaces its value.
+//
+// Returns the previous value and true if iv was already stored.
+func (t *IntervalTree[K, V]) Insert(iv Interval[K], value V) (V, bool) {
+	var old V
+	var replaced bool
+	t.root = t.insert(t.root, iv, value, &old, &replaced)
+	if !replaced {
+		t.size++
+	}
+	return old, replaced
+}
+
+// Get returns the value stored for exactly the interval iv.
+func (t *IntervalTree[K, V]) Get(iv Interval[K]) (V, bool) {
+	n := t.root
+	for n != nil {
+		switch c := compareIntervals(iv, n.iv); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes exactly the interval iv.
+//
+// Returns the removed value and true, or false if iv was not stored.
+func (t *IntervalTree[K, V]) Delete(iv Interval[K]) (V, bool) {
+	var old V
+	var deleted bool
+	t.root = t.delete(t.root, iv, &old, &deleted)
+	if deleted {
+		t.size--
+	}
+	return old, deleted
+}
+
+// EachOverlapping calls fn for every stored interval that overlaps q, in
+// order, until fn returns false.
+func (t *IntervalTree[K, V]) EachOverlapping(q Interval[K], fn func(iv Interval[K], value V) bool) {
+	if q.IsEmpty() {
+		return
+	}
+	t.walk(t.root, func(n *intervalNode[K, V]) (bool, bool) {
+		// Nothing in this subtree ends after q starts.
+		if n.maxHigh <= q.Low {
+			return false, false
+		}
+		return true, n.iv.Low < q.High
+	}, func(n *intervalNode[K, V]) bool {
+		if n.iv.Overlaps(q) {
+			return fn(n.iv, n.value)
+		}
+		return true
+	})
+}
+
+// Overlapping returns every stored interval that overlaps q, in order.
+func (t *IntervalTree[K, V]) Overlapping(q Interval[K]) []IntervalEntry[K, V] {
+	var result []IntervalEntry[K, V]
+	t.EachOverlapping(q, func(iv Interval[K], value V) bool {
+		result = append(result, IntervalEntry[K, V]{Interval: iv, Value: value})
+		return true
+	})
+	return result
+}
+
+// EachContaining calls fn for every stored interval that contains the point
+// p, in order, until fn returns false. This is a stabbing query.
+func (t *IntervalTree[K, V]) EachContaining(p K, fn func(iv Interval[K], value V) bool) {
+	t.walk(t.root, func(n *intervalNode[K, V]) (bool, bool) {
+		if n.maxHigh <= p {
+			return false, false
+		}
+		return true, n.iv.Low <= p
+	}, func(n *intervalNode[K, V]) bool {
+		if n.iv.Contains(p) {
+			return fn(n.iv, n.value)
+		}
+		return true
+	})
+}
+
+// Containing returns every stored interval that contains the point p, in
+// order.
+func (t *IntervalTree[K, V]) Containing(p K) []IntervalEntry[K, V] {
+	var result []IntervalEntry[K, V]
+	t.EachContaining(p, func(iv Interval[K], value V) bool {
+		result = append(result, IntervalEntry[K, V]{Interval: iv, Value: value})
+		return true
+	})
+	return result
+}
+
+// Each calls fn for every stored interval, ordered by Low then High, until
+// fn returns false.
+func (t *IntervalTree[K, V]) Each(fn func(iv Interval[K], value V) bool) {
+	t.walk(t.root, func(*intervalNode[K, V]) (bool, bool) {
+		return true, true
+	}, func(n *intervalNode[K, V]) bool {
+		return fn(n.iv, n.value)
+	})
+}
+
+// ToSlice returns all stored intervals with their values, ordered by Low
+// then High.
+func (t *IntervalTree[K, V]) ToSlice() []IntervalEntry[K, V] {
+	result := make([]IntervalEntry[K, V], 0, t.size)
+	t.Each(func(iv Interval[K], value V) bool {
+		result = append(result, IntervalEntry[K, V]{Interval: iv, Value: value})
+		return true
+	})
+	return result
+}
+
+// Merged returns the union of all stored intervals as a sorted list of
+// disjoint intervals. Overlapping and adjacent intervals are merged.
+func (t *IntervalTree[K, V]) Merged() []Interval[K] {
+	var result []Interval[K]
+	t.Each(func(iv Interval[K], _ V) bool {
+		if iv.IsEmpty() {
+			return true
+		}
+		if last := len(result) - 1; last >= 0 && iv.Low <= result[last].High {
+			result[last].High = max(result[last].High, iv.High)
+		} else {
+			result = append(result, iv)
+		}
+		return true
+	})
+	return result
+}
+
+// ToIntervalSet returns the union of all stored intervals as an IntervalSet.
+func (t *IntervalTree[K, V]) ToIntervalSet() IntervalSet[K] {
+	return IntervalSet[K]{intervals: t.Merged()}
+}
+
+// Len returns the number of stored intervals.
+func (t *IntervalTree[K, V]) Len() int {
+	return t.size
+}
+
+// IsEmpty checks if the tree is empty.
+func (t *IntervalTree[K, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear removes all intervals from the tree.
+func (t *IntervalTree[K, V]) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+// walk visits nodes in order. prune reports whether the subtree rooted at a
+// node may hold matches at all, and whether the node and its right subtree
+// may.
+func (t *IntervalTree[K, V]) walk(n *intervalNode[K, V], prune func(*intervalNode[K, V]) (bool, bool), visit func(*intervalNode[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	subtree, right := prune(n)
+	if !subtree {
+		return true
+	}
+	if !t.walk(n.left, prune, visit) {
+		return false
+	}
+	if !right {
+		return true
+	}
+	if !visit(n) {
+		return false
+	}
+	return t.walk(n.right, prune, visit)
+}
+
+func (t *IntervalTree[K, V]) insert(n *intervalNode[K, V], iv Interval[K], value V, old *V, replaced *bool) *intervalNode[K, V] {
+	if n == nil {
+		return &intervalNode[K, V]{iv: iv, value: value, maxHigh: iv.High, height: 1}
+	}
+	switch c := compareIntervals(iv, n.iv); {
+	case c < 0:
+		n.left = t.insert(n.left, iv, value, old, replaced)
+	case c > 0:
+		n.right = t.insert(n.right, iv, value, old, replaced)
+	default:
+		*old, *replaced = n.value, true
+		n.value = value
+		return n
+	}
+	return rebalanceInterval(n)
+}
+
+func (t *IntervalTree[K, V]) delete(n *intervalNode[K, V], iv Interval[K], old *V, deleted *bool) *intervalNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	switch c := compareIntervals(iv, n.iv); {
+	case c < 0:
+		n.left = t.delete(n.left, iv, old, deleted)
+	case c > 0:
+		n.right = t.delete(n.right, iv, old, deleted)
+	default:
+		*old, *deleted = n.value, true
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		succ := n.right
+		for succ.left != nil {
+			succ = succ.left
+		}
+		n.iv, n.value = succ.iv, succ.value
+		var ignored V
+		var found bool
+		n.right = t.delete(n.right, succ.iv, &ignored, &found)
+	}
+	return rebalanceInterval(n)
+}
+
+func compareIntervals[K cmp.Ordered](a, b Interval[K]) int {
+	if c := cmp.Compare(a.Low, b.Low); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.High, b.High)
+}
+
+func intervalHeight[K cmp.Ordered, V any](n *intervalNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func updateInterval[K cmp.Ordered, V any](n *intervalNode[K, V]) {
+	n.height = 1 + max(intervalHeight(n.left), intervalHeight(n.right))
+	n.maxHigh = n.iv.High
+	if n.left != nil {
+		n.maxHigh = max(n.maxHigh, n.left.maxHigh)
+	}
+	if n.right != nil {
+		n.maxHigh = max(n.maxHigh, n.right.maxHigh)
+	}
+}
+
+func rotateIntervalLeft[K cmp.Ordered, V any](n *intervalNode[K, V]) *intervalNode[K, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	updateInterval(n)
+	updateInterval(r)
+	return r
+}
+
+func rotateIntervalRight[K cmp.Ordered, V any](n *intervalNode[K, V]) *intervalNode[K, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	updateInterval(n)
+	updateInterval(l)
+	return l
+}
+
+// rebalanceInterval restores the AVL balance of n and its augmented fields.
+func rebalanceInterval[K cmp.Ordered, V any](n *intervalNode[K, V]) *intervalNode[K, V] {
+	updateInterval(n)
+	switch balance := intervalHeight(n.left) - intervalHeight(n.right); {
+	case balance > 1:
+		if intervalHeight(n.left.left) < intervalHeight(n.left.right) {
+			n.left = rotateIntervalLeft(n.left)
+		}
+		return rotateIntervalRight(n)
+	case balance < -1:
+		if intervalHeight(n.right.right) < intervalHeight(n.right.left) {
+			n.right = rotateIntervalRight(n.right)
+		}
+		return rotateIntervalLeft(n)
+	}
+	return n
+}
@@ -0,0 +1,195 @@
+package collections
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func iv(low, high int) Interval[int] {
+	return Interval[int]{Low: low, High: high}
+}
+
+func intervalsOf[V any](entries []IntervalEntry[int, V]) []Interval[int] {
+	result := make([]Interval[int], len(entries))
+	for i, e := range entries {
+		result[i] = e.Interval
+	}
+	return result
+}
+
+func TestIntervalTree_Queries(t *testing.T) {
+	tree := NewIntervalTree[int, string]()
+	tree.Insert(iv(15, 20), "a")
+	tree.Insert(iv(10, 30), "b")
+	tree.Insert(iv(17, 19), "c")
+	tree.Insert(iv(5, 20), "d")
+	tree.Insert(iv(12, 15), "e")
+	tree.Insert(iv(30, 40), "f")
+
+	expected := []Interval[int]{iv(10, 30), iv(12, 15), iv(15, 20), iv(17, 19)}
+	if result := intervalsOf(tree.Overlapping(iv(14, 18))); !reflect.DeepEqual(result, append([]Interval[int]{iv(5, 20)}, expected...)) {
+		t.Errorf("Overlapping: unexpected result %v", result)
+	}
+
+	// Half-open bounds: [30, 40) does not contain 40 and [10, 30) does not
+	// contain 30.
+	if result := intervalsOf(tree.Containing(30)); !reflect.DeepEqual(result, []Interval[int]{iv(30, 40)}) {
+		t.Errorf("Containing(30): unexpected result %v", result)
+	}
+	if result := tree.Containing(40); len(result) != 0 {
+		t.Errorf("Containing(40): expected nothing, but got %v", result)
+	}
+
+	if v, ok := tree.Get(iv(17, 19)); !ok || v != "c" {
+		t.Errorf("Expected c, but got %v %v", v, ok)
+	}
+	if old, replaced := tree.Insert(iv(17, 19), "C"); !replaced || old != "c" {
+		t.Errorf("Expected to replace c, but got %v %v", old, replaced)
+	}
+	if tree.Len() != 6 {
+		t.Errorf("Expected length 6, but got %v", tree.Len())
+	}
+}
+
+func TestIntervalTree_Delete(t *testing.T) {
+	tree := NewIntervalTree[int, int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(iv(i, i+2), i)
+	}
+
+	if v, ok := tree.Delete(iv(4, 6)); !ok || v != 4 {
+		t.Errorf("Expected to delete 4, but got %v %v", v, ok)
+	}
+	if _, ok := tree.Delete(iv(4, 6)); ok {
+		t.Errorf("Expected second delete to fail")
+	}
+	if result := intervalsOf(tree.Containing(5)); !reflect.DeepEqual(result, []Interval[int]{iv(5, 7)}) {
+		t.Errorf("Expected [5,7) only, but got %v", result)
+	}
+}
+
+func TestIntervalTree_Merged(t *testing.T) {
+	tree := NewIntervalTree[int, struct{}]()
+	for _, i := range []Interval[int]{iv(1, 3), iv(2, 4), iv(4, 6), iv(8, 9), iv(10, 12), iv(11, 11)} {
+		tree.Insert(i, struct{}{})
+	}
+
+	expected := []Interval[int]{iv(1, 6), iv(8, 9), iv(10, 12)}
+	if result := tree.Merged(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+	if !tree.ToIntervalSet().Equals(NewIntervalSet(expected...)) {
+		t.Errorf("Expected ToIntervalSet to match Merged")
+	}
+}
+
+func TestIntervalTree_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	tree := NewIntervalTree[int, int]()
+	reference := make(map[Interval[int]]int)
+
+	for i := 0; i < 3000; i++ {
+		low := rng.Intn(500)
+		cur := iv(low, low+1+rng.Intn(50))
+		if rng.Intn(4) == 0 {
+			tree.Delete(cur)
+			delete(reference, cur)
+		} else {
+			tree.Insert(cur, i)
+			reference[cur] = i
+		}
+	}
+
+	for q := 0; q < 200; q++ {
+		low := rng.Intn(550)
+		query := iv(low, low+rng.Intn(30)+1)
+
+		expected := make([]Interval[int], 0)
+		for cur := range reference {
+			if cur.Overlaps(query) {
+				expected = append(expected, cur)
+			}
+		}
+		sort.Slice(expected, func(i, j int) bool {
+			return compareIntervals(expected[i], expected[j]) < 0
+		})
+
+		if result := intervalsOf(tree.Overlapping(query)); !reflect.DeepEqual(result, expected) {
+			t.Fatalf("Overlapping(%v): expected %v, but got %v", query, expected, result)
+		}
+	}
+	if tree.Len() != len(reference) {
+		t.Errorf("Expected length %v, but got %v", len(reference), tree.Len())
+	}
+}
+
+func TestIntervalSet_AddRemove(t *testing.T) {
+	s := NewIntervalSet(iv(1, 3), iv(5, 7), iv(3, 4), iv(10, 10))
+
+	expected := []Interval[int]{iv(1, 4), iv(5, 7)}
+	if result := s.ToSlice(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	s.Add(iv(4, 5))
+	if result := s.ToSlice(); !reflect.DeepEqual(result, []Interval[int]{iv(1, 7)}) {
+		t.Errorf("Expected adjacent intervals to merge, but got %v", result)
+	}
+
+	s.Remove(iv(2, 3), iv(6, 10))
+	expected = []Interval[int]{iv(1, 2), iv(3, 6)}
+	if result := s.ToSlice(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	if !s.Contains(1, 3, 5) || s.Contains(2) || s.Contains(6) {
+		t.Errorf("Contains returned an unexpected result")
+	}
+	if !s.ContainsInterval(iv(3, 6)) || s.ContainsInterval(iv(1, 4)) {
+		t.Errorf("ContainsInterval returned an unexpected result")
+	}
+	if !s.Overlaps(iv(5, 9)) || s.Overlaps(iv(6, 9)) {
+		t.Errorf("Overlaps returned an unexpected result")
+	}
+}
+
+func TestIntervalSet_ValueCopy(t *testing.T) {
+	a := NewIntervalSet(iv(0, 1), iv(5, 6), iv(10, 11))
+	b := a
+	b.Add(iv(2, 3))
+	b.Remove(iv(10, 11))
+
+	expected := []Interval[int]{iv(0, 1), iv(5, 6), iv(10, 11)}
+	if result := a.ToSlice(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+	expected = []Interval[int]{iv(0, 1), iv(2, 3), iv(5, 6)}
+	if result := b.ToSlice(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestIntervalSet_Algebra(t *testing.T) {
+	a := NewIntervalSet(iv(0, 10), iv(20, 30))
+	b := NewIntervalSet(iv(5, 25))
+
+	if result := a.Union(b); !result.Equals(NewIntervalSet(iv(0, 30))) {
+		t.Errorf("Union: unexpected result %v", result.ToSlice())
+	}
+	if result := a.Intersection(b); !result.Equals(NewIntervalSet(iv(5, 10), iv(20, 25))) {
+		t.Errorf("Intersection: unexpected result %v", result.ToSlice())
+	}
+	if result := a.Difference(b); !result.Equals(NewIntervalSet(iv(0, 5), iv(25, 30))) {
+		t.Errorf("Difference: unexpected result %v", result.ToSlice())
+	}
+	if a.Len() != 2 || b.Len() != 1 {
+		t.Errorf("Expected operands to be left untouched")
+	}
+
+	a.Clear()
+	if !a.IsEmpty() {
+		t.Errorf("Expected empty set after Clear")
+	}
+}
@@ -0,0 +1,319 @@
+package collections
+
+// ListElement is a handle to an element of a List.
+//
+// A handle stays valid, and keeps pointing at the same value, while the
+// element moves around its list or is spliced into another list. It becomes
+// invalid once the element is removed.
+type ListElement[T any] struct {
+	Value T
+
+	next, prev *ListElement[T]
+	list       *List[T]
+}
+
+// Next returns the next list element or nil.
+func (e *ListElement[T]) Next() *ListElement[T] {
+	if n := e.next; e.list != nil && n != &e.list.root {
+		return n
+	}
+	return nil
+}
+
+// Prev returns the previous list element or nil.
+func (e *ListElement[T]) Prev() *ListElement[T] {
+	if p := e.prev; e.list != nil && p != &e.list.root {
+		return p
+	}
+	return nil
+}
+
+type List[T any] struct {
+	root ListElement[T]
+	len  int
+}
+
+// NewList creates a new list with the given elements.
+//
+// The elements parameter is a variadic input of any type.
+// Returns a pointer to a List containing the elements in the given order.
+func NewList[T any](elements ...T) *List[T] {
+	l := new(List[T]).init()
+	for _, e := range elements {
+		l.PushBack(e)
+	}
+	return l
+}
+
+// ListFromSlice creates a new list from a slice of elements.
+//
+// The slice is not modified.
+// Returns a pointer to a List containing the elements in the slice order.
+func ListFromSlice[T any](elements []T) *List[T] {
+	return NewList(elements...)
+}
+
+// Len returns the number of elements in the list.
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+// IsEmpty checks if the list is empty.
+//
+// Returns true if the list has no elements, otherwise false.
+func (l *List[T]) IsEmpty() bool {
+	return l.len == 0
+}
+
+// Front returns the first element of the list or nil if the list is empty.
+func (l *List[T]) Front() *ListElement[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns the last element of the list or nil if the list is empty.
+func (l *List[T]) Back() *ListElement[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// PushFront inserts a new element at the front of the list.
+//
+// Returns the handle of the new element.
+func (l *List[T]) PushFront(value T) *ListElement[T] {
+	l.lazyInit()
+	return l.insert(&ListElement[T]{Value: value}, &l.root)
+}
+
+// PushBack inserts a new element at the back of the list.
+//
+// Returns the handle of the new element.
+func (l *List[T]) PushBack(value T) *ListElement[T] {
+	l.lazyInit()
+	return l.insert(&ListElement[T]{Value: value}, l.root.prev)
+}
+
+// InsertBefore inserts a new element immediately before mark.
+//
+// Returns the handle of the new element, or nil if mark is not an element
+// of the list.
+func (l *List[T]) InsertBefore(value T, mark *ListElement[T]) *ListElement[T] {
+	if mark == nil || mark.list != l {
+		return nil
+	}
+	return l.insert(&ListElement[T]{Value: value}, mark.prev)
+}
+
+// InsertAfter inserts a new element immediately after mark.
+//
+// Returns the handle of the new element, or nil if mark is not an element
+// of the list.
+func (l *List[T]) InsertAfter(value T, mark *ListElement[T]) *ListElement[T] {
+	if mark == nil || mark.list != l {
+		return nil
+	}
+	return l.insert(&ListElement[T]{Value: value}, mark)
+}
+
+// Remove removes e from the list.
+//
+// Returns the value of e and true, or false if e is not an element of the
+// list.
+func (l *List[T]) Remove(e *ListElement[T]) (T, bool) {
+	if e == nil || e.list != l {
+		var zero T
+		return zero, false
+	}
+	l.unlink(e)
+	return e.Value, true
+}
+
+// MoveToFront moves e to the front of the list.
+//
+// The list is not modified if e is not an element of the list.
+func (l *List[T]) MoveToFront(e *ListElement[T]) {
+	if e == nil || e.list != l || l.root.next == e {
+		return
+	}
+	l.move(e, &l.root)
+}
+
+// MoveToBack moves e to the back of the list.
+//
+// The list is not modified if e is not an element of the list.
+func (l *List[T]) MoveToBack(e *ListElement[T]) {
+	if e == nil || e.list != l || l.root.prev == e {
+		return
+	}
+	l.move(e, l.root.prev)
+}
+
+// MoveBefore moves e immediately before mark.
+//
+// The list is not modified if e or mark is not an element of the list, or
+// if e == mark.
+func (l *List[T]) MoveBefore(e, mark *ListElement[T]) {
+	if e == nil || mark == nil || e.list != l || mark.list != l || e == mark {
+		return
+	}
+	l.move(e, mark.prev)
+}
+
+// MoveAfter moves e immediately after mark.
+//
+// The list is not modified if e or mark is not an element of the list, or
+// if e == mark.
+func (l *List[T]) MoveAfter(e, mark *ListElement[T]) {
+	if e == nil || mark == nil || e.list != l || mark.list != l || e == mark {
+		return
+	}
+	l.move(e, mark)
+}
+
+// Splice moves all elements of other into l, immediately after mark. If mark
+// is nil, the elements are appended to the back of l.
+//
+// The moved elements keep their order and their handles stay valid; other
+// is left empty. Splicing a list into itself, or using a mark that is not an
+// element of l, has no effect.
+// It runs in O(len(other)) time.
+func (l *List[T]) Splice(mark *ListElement[T], other *List[T]) {
+	if other == nil || other == l || other.len == 0 {
+		return
+	}
+	l.lazyInit()
+	if mark == nil {
+		mark = l.root.prev
+	} else if mark.list != l {
+		return
+	}
+
+	first, last := other.root.next, other.root.prev
+	for e := first; e != &other.root; e = e.next {
+		e.list = l
+	}
+
+	after := mark.next
+	mark.next = first
+	first.prev = mark
+	last.next = after
+	after.prev = last
+	l.len += other.len
+
+	other.init()
+}
+
+// Reverse reverses the order of the elements in place.
+//
+// Handles stay valid.
+func (l *List[T]) Reverse() {
+	if l.len < 2 {
+		return
+	}
+	e := &l.root
+	for {
+		e.next, e.prev = e.prev, e.next
+		e = e.prev
+		if e == &l.root {
+			break
+		}
+	}
+}
+
+// Each calls fn for every element from front to back until fn returns false.
+func (l *List[T]) Each(fn func(value T) bool) {
+	for e := l.Front(); e != nil; e = e.Next() {
+		if !fn(e.Value) {
+			return
+		}
+	}
+}
+
+// EachReverse calls fn for every element from back to front until fn returns
+// false.
+func (l *List[T]) EachReverse(fn func(value T) bool) {
+	for e := l.Back(); e != nil; e = e.Prev() {
+		if !fn(e.Value) {
+			return
+		}
+	}
+}
+
+// ToSlice returns a slice containing all elements of the list, front to back.
+//
+// It does not modify the list.
+func (l *List[T]) ToSlice() []T {
+	result := make([]T, 0, l.len)
+	for e := l.Front(); e != nil; e = e.Next() {
+		result = append(result, e.Value)
+	}
+	return result
+}
+
+// Copy returns a new list with the same elements.
+//
+// Handles of l are not valid for the copy.
+func (l *List[T]) Copy() *List[T] {
+	return NewList(l.ToSlice()...)
+}
+
+// Clear removes all elements from the list. Outstanding handles become
+// invalid.
+func (l *List[T]) Clear() {
+	for e := l.Front(); e != nil; {
+		next := e.Next()
+		e.next, e.prev, e.list = nil, nil, nil
+		e = next
+	}
+	l.init()
+}
+
+func (l *List[T]) init() *List[T] {
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.len = 0
+	return l
+}
+
+// lazyInit lets the zero value of List be used as an empty list.
+func (l *List[T]) lazyInit() {
+	if l.root.next == nil {
+		l.init()
+	}
+}
+
+// insert links e after at.
+func (l *List[T]) insert(e, at *ListElement[T]) *ListElement[T] {
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+	e.list = l
+	l.len++
+	return e
+}
+
+func (l *List[T]) unlink(e *ListElement[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.next, e.prev, e.list = nil, nil, nil
+	l.len--
+}
+
+// move relinks e after at.
+func (l *List[T]) move(e, at *ListElement[T]) {
+	if e == at || at.next == e {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+}
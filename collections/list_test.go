@@ -0,0 +1,171 @@
+package collections
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestList_PushAndInsert(t *testing.T) {
+	l := NewList[int]()
+	two := l.PushBack(2)
+	l.PushFront(1)
+	four := l.PushBack(4)
+	l.InsertAfter(3, two)
+	l.InsertBefore(0, l.Front())
+	l.InsertAfter(5, four)
+
+	expected := []int{0, 1, 2, 3, 4, 5}
+	if result := l.ToSlice(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+	if l.Len() != 6 {
+		t.Errorf("Expected length 6, but got %v", l.Len())
+	}
+	if l.Front().Value != 0 || l.Back().Value != 5 {
+		t.Errorf("Expected front 0 and back 5, but got %v and %v", l.Front().Value, l.Back().Value)
+	}
+}
+
+func TestList_ZeroValue(t *testing.T) {
+	var l List[string]
+	if !l.IsEmpty() || l.Front() != nil {
+		t.Errorf("Expected zero value list to be empty")
+	}
+	l.PushBack("a")
+	if result := l.ToSlice(); !reflect.DeepEqual(result, []string{"a"}) {
+		t.Errorf("Expected [a], but got %v", result)
+	}
+}
+
+func TestList_Remove(t *testing.T) {
+	l := NewList(1, 2, 3)
+	mid := l.Front().Next()
+
+	if v, ok := l.Remove(mid); !ok || v != 2 {
+		t.Errorf("Expected to remove 2, but got %v %v", v, ok)
+	}
+	if _, ok := l.Remove(mid); ok {
+		t.Errorf("Expected removing a stale handle to fail")
+	}
+	if l.InsertAfter(9, mid) != nil {
+		t.Errorf("Expected inserting after a stale handle to fail")
+	}
+
+	expected := []int{1, 3}
+	if result := l.ToSlice(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestList_Move(t *testing.T) {
+	l := NewList[int]()
+	e1 := l.PushBack(1)
+	e2 := l.PushBack(2)
+	e3 := l.PushBack(3)
+
+	l.MoveToFront(e3)
+	expected := []int{3, 1, 2}
+	if result := l.ToSlice(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("MoveToFront: expected %v, but got %v", expected, result)
+	}
+
+	l.MoveToBack(e3)
+	l.MoveBefore(e2, e1)
+	expected = []int{2, 1, 3}
+	if result := l.ToSlice(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("MoveBefore: expected %v, but got %v", expected, result)
+	}
+
+	l.MoveAfter(e2, e3)
+	expected = []int{1, 3, 2}
+	if result := l.ToSlice(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("MoveAfter: expected %v, but got %v", expected, result)
+	}
+
+	other := NewList(7)
+	l.MoveToFront(other.Front())
+	if l.Len() != 3 || other.Len() != 1 {
+		t.Errorf("Expected foreign handle to be ignored")
+	}
+}
+
+func TestList_Splice(t *testing.T) {
+	l := NewList(1, 2, 5)
+	other := NewList[int]()
+	three := other.PushBack(3)
+	other.PushBack(4)
+
+	l.Splice(l.Front().Next(), other)
+
+	expected := []int{1, 2, 3, 4, 5}
+	if result := l.ToSlice(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+	if !other.IsEmpty() || l.Len() != 5 {
+		t.Errorf("Expected other to be empty and l to have 5 elements")
+	}
+
+	// Handles follow the element into the new list.
+	l.MoveToFront(three)
+	if l.Front().Value != 3 {
+		t.Errorf("Expected spliced handle to be usable on the target list")
+	}
+
+	l.Splice(nil, NewList(6, 7))
+	if l.Back().Value != 7 || l.Len() != 7 {
+		t.Errorf("Expected splice with nil mark to append")
+	}
+}
+
+func TestList_Reverse(t *testing.T) {
+	l := NewList(1, 2, 3, 4)
+	first := l.Front()
+	l.Reverse()
+
+	expected := []int{4, 3, 2, 1}
+	if result := l.ToSlice(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+	if l.Back() != first {
+		t.Errorf("Expected handles to survive Reverse")
+	}
+
+	var backwards []int
+	l.EachReverse(func(v int) bool {
+		backwards = append(backwards, v)
+		return true
+	})
+	if !reflect.DeepEqual(backwards, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected reverse iteration to follow prev links, but got %v", backwards)
+	}
+}
+
+func TestList_Each(t *testing.T) {
+	l := ListFromSlice([]string{"a", "b", "c"})
+	var seen []string
+	l.Each(func(v string) bool {
+		seen = append(seen, v)
+		return v != "b"
+	})
+
+	if !reflect.DeepEqual(seen, []string{"a", "b"}) {
+		t.Errorf("Expected iteration to stop after b, but got %v", seen)
+	}
+}
+
+func TestList_Interop(t *testing.T) {
+	set := NewOrderedSet(3, 1, 2)
+	l := ListFromSlice(set.ToSlice())
+	l.Reverse()
+	stack := NewStack(l.ToSlice()...)
+
+	if stack.Pop() != 3 {
+		t.Errorf("Expected 3 on top of the stack")
+	}
+
+	cp := l.Copy()
+	cp.Clear()
+	if l.Len() != 3 || !cp.IsEmpty() {
+		t.Errorf("Expected Copy to be independent of the original")
+	}
+}
@@ -0,0 +1,204 @@
+package collections
+
+import "sync/atomic"
+
+// Both structures below allocate a fresh node for every push and never reuse
+// nodes. The garbage collector keeps a node alive while any goroutine still
+// holds a pointer to it, so a compare-and-swap can never succeed against a
+// recycled node and the ABA problem cannot occur.
+
+type lockFreeNode[T any] struct {
+	value T
+	next  atomic.Pointer[lockFreeNode[T]]
+}
+
+type LockFreeStack[T any] struct {
+	top  atomic.Pointer[lockFreeNode[T]]
+	size atomic.Int64
+}
+
+// NewLockFreeStack creates a new lock-free stack with the given elements.
+//
+// The last element is on top of the stack, as with NewStack.
+// The stack is safe for concurrent use by multiple goroutines.
+func NewLockFreeStack[T any](elements ...T) *LockFreeStack[T] {
+	s := &LockFreeStack[T]{}
+	for _, e := range elements {
+		s.Push(e)
+	}
+	return s
+}
+
+// Push adds an element to the top of the stack.
+//
+// element: the element to be added to the stack
+func (s *LockFreeStack[T]) Push(element T) {
+	n := &lockFreeNode[T]{value: element}
+	for {
+		top := s.top.Load()
+		n.next.Store(top)
+		if s.top.CompareAndSwap(top, n) {
+			s.size.Add(1)
+			return
+		}
+	}
+}
+
+// Pop removes and returns the top element from the stack.
+//
+// Returns the zero value of T if the stack is empty. Use TryPop to tell an
+// empty stack apart from a stored zero value.
+func (s *LockFreeStack[T]) Pop() T {
+	element, _ := s.TryPop()
+	return element
+}
+
+// TryPop removes and returns the top element from the stack.
+//
+// Returns false if the stack is empty.
+func (s *LockFreeStack[T]) TryPop() (T, bool) {
+	for {
+		top := s.top.Load()
+		if top == nil {
+			var zero T
+			return zero, false
+		}
+		if s.top.CompareAndSwap(top, top.next.Load()) {
+			s.size.Add(-1)
+			return top.value, true
+		}
+	}
+}
+
+// Peek returns the top element of the stack without removing it.
+//
+// Returns the zero value of T if the stack is empty.
+func (s *LockFreeStack[T]) Peek() T {
+	if top := s.top.Load(); top != nil {
+		return top.value
+	}
+	var zero T
+	return zero
+}
+
+// IsEmpty checks if the stack is empty.
+//
+// Under concurrent use the answer may be stale by the time it is returned.
+func (s *LockFreeStack[T]) IsEmpty() bool {
+	return s.top.Load() == nil
+}
+
+// Size returns the number of elements in the stack.
+//
+// Under concurrent use the answer is approximate.
+func (s *LockFreeStack[T]) Size() int {
+	return max(0, int(s.size.Load()))
+}
+
+type LockFreeQueue[T any] struct {
+	head atomic.Pointer[lockFreeNode[T]]
+	tail atomic.Pointer[lockFreeNode[T]]
+	size atomic.Int64
+}
+
+// NewLockFreeQueue creates a new lock-free FIFO queue with the given elements.
+//
+// The queue is a Michael-Scott queue and is safe for any number of
+// concurrent producers and consumers. The zero value is an empty queue
+// ready to use.
+func NewLockFreeQueue[T any](elements ...T) *LockFreeQueue[T] {
+	q := &LockFreeQueue[T]{}
+	for _, e := range elements {
+		q.Push(e)
+	}
+	return q
+}
+
+// Push adds an element to the back of the queue.
+//
+// element: the element to be added to the queue
+func (q *LockFreeQueue[T]) Push(element T) {
+	q.init()
+	n := &lockFreeNode[T]{value: element}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			continue
+		}
+		if next != nil {
+			// Another producer linked a node but has not swung the tail yet.
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, n) {
+			q.tail.CompareAndSwap(tail, n)
+			q.size.Add(1)
+			return
+		}
+	}
+}
+
+// Pop removes and returns the element at the front of the queue.
+//
+// Returns the zero value of T if the queue is empty. Use TryPop to tell an
+// empty queue apart from a stored zero value.
+func (q *LockFreeQueue[T]) Pop() T {
+	element, _ := q.TryPop()
+	return element
+}
+
+// TryPop removes and returns the element at the front of the queue.
+//
+// Returns false if the queue is empty.
+func (q *LockFreeQueue[T]) TryPop() (T, bool) {
+	q.init()
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+		if next == nil {
+			var zero T
+			return zero, false
+		}
+		if head == tail {
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		element := next.value
+		if q.head.CompareAndSwap(head, next) {
+			q.size.Add(-1)
+			return element, true
+		}
+	}
+}
+
+// IsEmpty checks if the queue is empty.
+//
+// Under concurrent use the answer may be stale by the time it is returned.
+func (q *LockFreeQueue[T]) IsEmpty() bool {
+	q.init()
+	return q.head.Load().next.Load() == nil
+}
+
+// Size returns the number of elements in the queue.
+//
+// Under concurrent use the answer is approximate.
+func (q *LockFreeQueue[T]) Size() int {
+	return max(0, int(q.size.Load()))
+}
+
+// init installs the sentinel node of a zero-value queue on first use. The
+// head is set before the tail, and the head cannot move past the sentinel
+// until a push has seen a tail, so the tail always starts at the sentinel
+// that won the head.
+func (q *LockFreeQueue[T]) init() {
+	if q.tail.Load() != nil {
+		return
+	}
+	q.head.CompareAndSwap(nil, &lockFreeNode[T]{})
+	q.tail.CompareAndSwap(nil, q.head.Load())
+}
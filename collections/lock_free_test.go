@@ -0,0 +1,239 @@
+package collections
+
+import (
+	"sync"
+	"testing"
+)
+
+const (
+	stressWorkers = 8
+	stressItems   = 2000
+)
+
+func TestLockFreeStack_Order(t *testing.T) {
+	s := NewLockFreeStack(1, 2, 3)
+	if s.Size() != 3 {
+		t.Errorf("Expected size 3, but got %v", s.Size())
+	}
+	if s.Peek() != 3 {
+		t.Errorf("Expected peek 3, but got %v", s.Peek())
+	}
+	for _, e := range []int{3, 2, 1} {
+		if v := s.Pop(); v != e {
+			t.Errorf("Expected %v, but got %v", e, v)
+		}
+	}
+	if !s.IsEmpty() {
+		t.Errorf("Expected empty stack")
+	}
+	if _, ok := s.TryPop(); ok {
+		t.Errorf("Expected TryPop on an empty stack to fail")
+	}
+}
+
+func TestLockFreeQueue_Order(t *testing.T) {
+	q := NewLockFreeQueue(1, 2, 3)
+	if q.Size() != 3 {
+		t.Errorf("Expected size 3, but got %v", q.Size())
+	}
+	for _, e := range []int{1, 2, 3} {
+		if v := q.Pop(); v != e {
+			t.Errorf("Expected %v, but got %v", e, v)
+		}
+	}
+	if !q.IsEmpty() {
+		t.Errorf("Expected empty queue")
+	}
+	if _, ok := q.TryPop(); ok {
+		t.Errorf("Expected TryPop on an empty queue to fail")
+	}
+}
+
+// stress pushes stressItems distinct values from each of stressWorkers
+// producers while the same number of consumers pop, then checks that every
+// value came out exactly once.
+func stress(t *testing.T, push func(int), tryPop func() (int, bool)) {
+	total := stressWorkers * stressItems
+	seen := make([]int32, total)
+	var mu sync.Mutex
+	var popped int
+
+	var wg sync.WaitGroup
+	for w := 0; w < stressWorkers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < stressItems; i++ {
+				push(w*stressItems + i)
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				finished := popped == total
+				mu.Unlock()
+				if finished {
+					return
+				}
+				if v, ok := tryPop(); ok {
+					mu.Lock()
+					seen[v]++
+					popped++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for v, n := range seen {
+		if n != 1 {
+			t.Fatalf("Expected value %v to be popped once, but got %v", v, n)
+		}
+	}
+}
+
+func TestLockFreeStack_Stress(t *testing.T) {
+	s := NewLockFreeStack[int]()
+	stress(t, s.Push, s.TryPop)
+	if !s.IsEmpty() || s.Size() != 0 {
+		t.Errorf("Expected empty stack, but got size %v", s.Size())
+	}
+}
+
+func TestLockFreeQueue_Stress(t *testing.T) {
+	q := NewLockFreeQueue[int]()
+	stress(t, q.Push, q.TryPop)
+	if !q.IsEmpty() || q.Size() != 0 {
+		t.Errorf("Expected empty queue, but got size %v", q.Size())
+	}
+}
+
+func TestLockFreeQueue_ZeroValue(t *testing.T) {
+	var q LockFreeQueue[int]
+	if !q.IsEmpty() {
+		t.Errorf("Expected empty queue")
+	}
+	if _, ok := q.TryPop(); ok {
+		t.Errorf("Expected TryPop on an empty queue to fail")
+	}
+	q.Push(1)
+	q.Push(2)
+	if v := q.Pop(); v != 1 {
+		t.Errorf("Expected 1, but got %v", v)
+	}
+
+	// The first use races to install the sentinel.
+	var r LockFreeQueue[int]
+	stress(t, r.Push, r.TryPop)
+	if !r.IsEmpty() || r.Size() != 0 {
+		t.Errorf("Expected empty queue, but got size %v", r.Size())
+	}
+}
+
+func TestLockFreeQueue_PerProducerOrder(t *testing.T) {
+	q := NewLockFreeQueue[[2]int]()
+	var wg sync.WaitGroup
+	for w := 0; w < stressWorkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < stressItems; i++ {
+				q.Push([2]int{w, i})
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	last := make([]int, stressWorkers)
+	for i := range last {
+		last[i] = -1
+	}
+	for !q.IsEmpty() {
+		v := q.Pop()
+		if v[1] <= last[v[0]] {
+			t.Fatalf("Expected FIFO order for producer %v, got %v after %v", v[0], v[1], last[v[0]])
+		}
+		last[v[0]] = v[1]
+	}
+}
+
+type mutexStack[T comparable] struct {
+	mu sync.Mutex
+	s  Stack[T]
+}
+
+func (m *mutexStack[T]) Push(e T) {
+	m.mu.Lock()
+	m.s.Push(e)
+	m.mu.Unlock()
+}
+
+func (m *mutexStack[T]) Pop() T {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.s.Pop()
+}
+
+type mutexQueue[T any] struct {
+	mu sync.Mutex
+	l  *List[T]
+}
+
+func (m *mutexQueue[T]) Push(e T) {
+	m.mu.Lock()
+	m.l.PushBack(e)
+	m.mu.Unlock()
+}
+
+func (m *mutexQueue[T]) Pop() T {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if front := m.l.Front(); front != nil {
+		e, _ := m.l.Remove(front)
+		return e
+	}
+	var zero T
+	return zero
+}
+
+func BenchmarkLockFreeStack(b *testing.B) {
+	s := NewLockFreeStack[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s.Push(1)
+			s.Pop()
+		}
+	})
+}
+
+func BenchmarkMutexStack(b *testing.B) {
+	s := &mutexStack[int]{s: NewStack[int]()}
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s.Push(1)
+			s.Pop()
+		}
+	})
+}
+
+func BenchmarkLockFreeQueue(b *testing.B) {
+	q := NewLockFreeQueue[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.Push(1)
+			q.Pop()
+		}
+	})
+}
+
+func BenchmarkMutexQueue(b *testing.B) {
+	q := &mutexQueue[int]{l: NewList[int]()}
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.Push(1)
+			q.Pop()
+		}
+	})
+}
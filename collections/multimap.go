@@ -0,0 +1,351 @@
+package collections
+
+// MultiMapValues selects the collection a MultiMap keeps for each key.
+type MultiMapValues int
+
+const (
+	// ListValues keeps every value in insertion order, duplicates included.
+	ListValues MultiMapValues = iota
+	// SetValues keeps unique values in no particular order.
+	SetValues
+	// OrderedSetValues keeps unique values in insertion order.
+	OrderedSetValues
+)
+
+// valueCollection is the per-key container of a MultiMap.
+type valueCollection[V comparable] interface {
+	add(v V) bool
+	remove(v V) bool
+	contains(v V) bool
+	len() int
+	toSlice() []V
+}
+
+type listValues[V comparable] struct {
+	elements []V
+}
+
+func (c *listValues[V]) add(v V) bool {
+	c.elements = append(c.elements, v)
+	return true
+}
+
+func (c *listValues[V]) remove(v V) bool {
+	n := len(c.elements)
+	c.elements = RemoveElement(c.elements, v)
+	return len(c.elements) < n
+}
+
+func (c *listValues[V]) contains(v V) bool {
+	for _, e := range c.elements {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *listValues[V]) len() int     { return len(c.elements) }
+func (c *listValues[V]) toSlice() []V { return append([]V{}, c.elements...) }
+
+type setValues[V comparable] struct {
+	set Set[V]
+}
+
+func (c *setValues[V]) add(v V) bool {
+	if c.set.Contains(v) {
+		return false
+	}
+	c.set.Add(v)
+	return true
+}
+
+func (c *setValues[V]) remove(v V) bool {
+	if !c.set.Contains(v) {
+		return false
+	}
+	c.set.Remove(v)
+	return true
+}
+
+func (c *setValues[V]) contains(v V) bool { return c.set.Contains(v) }
+func (c *setValues[V]) len() int          { return c.set.Len() }
+func (c *setValues[V]) toSlice() []V      { return c.set.ToSlice() }
+
+type orderedSetValues[V comparable] struct {
+	set OrderedSet[V]
+}
+
+func (c *orderedSetValues[V]) add(v V) bool {
+	if c.set.Contains(v) {
+		return false
+	}
+	c.set.Add(v)
+	return true
+}
+
+func (c *orderedSetValues[V]) remove(v V) bool {
+	if !c.set.Contains(v) {
+		return false
+	}
+	c.set.Remove(v)
+	return true
+}
+
+func (c *orderedSetValues[V]) contains(v V) bool { return c.set.Contains(v) }
+func (c *orderedSetValues[V]) len() int          { return c.set.Len() }
+func (c *orderedSetValues[V]) toSlice() []V      { return c.set.ToSlice() }
+
+type MultiMap[K, V comparable] struct {
+	keys    OrderedSet[K]
+	entries map[K]valueCollection[V]
+	kind    MultiMapValues
+	size    int
+	// inverse is the view returned by Inverse. Once it exists, every change
+	// to either map is applied to both.
+	inverse *MultiMap[V, K]
+}
+
+// NewMultiMap creates an empty map from keys to collections of values.
+//
+// kind selects whether each key keeps a list, a Set or an OrderedSet of
+// values. Keys are kept in the order they were first added.
+// Returns a pointer to the new MultiMap.
+func NewMultiMap[K, V comparable](kind MultiMapValues) *MultiMap[K, V] {
+	return &MultiMap[K, V]{
+		keys:    NewOrderedSet[K](),
+		entries: make(map[K]valueCollection[V]),
+		kind:    kind,
+	}
+}
+
+// MultiMapFromSlice groups items by the key returned for each of them.
+//
+// Returns a pointer to a MultiMap from each key to its items.
+func MultiMapFromSlice[T, K comparable](items []T, key func(T) K, kind MultiMapValues) *MultiMap[K, T] {
+	return MultiMapFromSliceWith(items, key, func(item T) T { return item }, kind)
+}
+
+// MultiMapFromSliceWith groups the value returned for each item by the key
+// returned for it.
+//
+// Returns a pointer to a MultiMap from each key to its values.
+func MultiMapFromSliceWith[T any, K, V comparable](items []T, key func(T) K, value func(T) V, kind MultiMapValues) *MultiMap[K, V] {
+	m := NewMultiMap[K, V](kind)
+	for _, item := range items {
+		m.Put(key(item), value(item))
+	}
+	return m
+}
+
+// Put adds value to the collection of key.
+//
+// Returns false if the collection is a set that already holds value.
+func (m *MultiMap[K, V]) Put(key K, value V) bool {
+	if !m.put(key, value) {
+		return false
+	}
+	if m.inverse != nil {
+		m.inverse.put(value, key)
+	}
+	return true
+}
+
+func (m *MultiMap[K, V]) put(key K, value V) bool {
+	c, ok := m.entries[key]
+	if !ok {
+		c = m.newCollection()
+		m.entries[key] = c
+		m.keys.Add(key)
+	}
+	if !c.add(value) {
+		return false
+	}
+	m.size++
+	return true
+}
+
+// PutAll adds every value to the collection of key.
+//
+// Returns the number of values that were added.
+func (m *MultiMap[K, V]) PutAll(key K, values ...V) int {
+	added := 0
+	for _, v := range values {
+		if m.Put(key, v) {
+			added++
+		}
+	}
+	return added
+}
+
+// Get returns a copy of the values of key, or nil if the key is absent.
+func (m *MultiMap[K, V]) Get(key K) []V {
+	if c, ok := m.entries[key]; ok {
+		return c.toSlice()
+	}
+	return nil
+}
+
+// Count returns the number of values stored for key.
+func (m *MultiMap[K, V]) Count(key K) int {
+	if c, ok := m.entries[key]; ok {
+		return c.len()
+	}
+	return 0
+}
+
+// Remove removes one occurrence of value from the collection of key. The key
+// is dropped once its collection is empty.
+//
+// Returns false if the entry was not present.
+func (m *MultiMap[K, V]) Remove(key K, value V) bool {
+	if !m.remove(key, value) {
+		return false
+	}
+	if m.inverse != nil {
+		m.inverse.remove(value, key)
+	}
+	return true
+}
+
+func (m *MultiMap[K, V]) remove(key K, value V) bool {
+	c, ok := m.entries[key]
+	if !ok || !c.remove(value) {
+		return false
+	}
+	m.size--
+	if c.len() == 0 {
+		m.dropKey(key)
+	}
+	return true
+}
+
+// RemoveAll removes key and all of its values.
+//
+// Returns the removed values, or nil if the key was absent.
+func (m *MultiMap[K, V]) RemoveAll(key K) []V {
+	c, ok := m.entries[key]
+	if !ok {
+		return nil
+	}
+	values := c.toSlice()
+	m.size -= len(values)
+	m.dropKey(key)
+	if m.inverse != nil {
+		for _, v := range values {
+			m.inverse.remove(v, key)
+		}
+	}
+	return values
+}
+
+// ContainsKey checks if all the given keys have at least one value.
+func (m *MultiMap[K, V]) ContainsKey(keys ...K) bool {
+	return m.keys.Contains(keys...)
+}
+
+// ContainsEntry checks if value is stored for key.
+func (m *MultiMap[K, V]) ContainsEntry(key K, value V) bool {
+	c, ok := m.entries[key]
+	return ok && c.contains(value)
+}
+
+// ContainsValue checks if value is stored for any key.
+func (m *MultiMap[K, V]) ContainsValue(value V) bool {
+	for _, c := range m.entries {
+		if c.contains(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Keys returns the keys in the order they were first added.
+func (m *MultiMap[K, V]) Keys() []K {
+	return m.keys.ToSlice()
+}
+
+// Values returns all values, grouped by key in the order of Keys.
+func (m *MultiMap[K, V]) Values() []V {
+	values := make([]V, 0, m.size)
+	for _, k := range m.keys.elements {
+		values = append(values, m.entries[k].toSlice()...)
+	}
+	return values
+}
+
+// Each calls fn for every key and value pair, in the order of Values, until
+// fn returns false.
+func (m *MultiMap[K, V]) Each(fn func(key K, value V) bool) {
+	for _, k := range m.Keys() {
+		for _, v := range m.entries[k].toSlice() {
+			if !fn(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Inverse returns a view from each value to the keys that hold it, using the
+// same kind of value collection.
+//
+// The view is backed by m: changes to either map show up in the other, and
+// the inverse of the view is m itself. The view is built on the first call
+// and kept up to date from then on, which costs every later write a second
+// update.
+func (m *MultiMap[K, V]) Inverse() *MultiMap[V, K] {
+	if m.inverse == nil {
+		inv := NewMultiMap[V, K](m.kind)
+		m.Each(func(k K, v V) bool {
+			inv.put(v, k)
+			return true
+		})
+		m.inverse, inv.inverse = inv, m
+	}
+	return m.inverse
+}
+
+// KeyCount returns the number of distinct keys.
+func (m *MultiMap[K, V]) KeyCount() int {
+	return m.keys.Len()
+}
+
+// Len returns the total number of values across all keys.
+func (m *MultiMap[K, V]) Len() int {
+	return m.size
+}
+
+// IsEmpty checks if the map holds no values.
+func (m *MultiMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Clear removes all keys and values.
+func (m *MultiMap[K, V]) Clear() {
+	m.clear()
+	if m.inverse != nil {
+		m.inverse.clear()
+	}
+}
+
+func (m *MultiMap[K, V]) clear() {
+	m.keys.Clear()
+	m.entries = make(map[K]valueCollection[V])
+	m.size = 0
+}
+
+func (m *MultiMap[K, V]) dropKey(key K) {
+	delete(m.entries, key)
+	m.keys.Remove(key)
+}
+
+func (m *MultiMap[K, V]) newCollection() valueCollection[V] {
+	switch m.kind {
+	case SetValues:
+		return &setValues[V]{set: NewSet[V]()}
+	case OrderedSetValues:
+		return &orderedSetValues[V]{set: NewOrderedSet[V]()}
+	default:
+		return &listValues[V]{}
+	}
+}
@@ -0,0 +1,172 @@
+package collections
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestMultiMap_List(t *testing.T) {
+	m := NewMultiMap[string, string](ListValues)
+	m.Put("Accept", "text/html")
+	m.PutAll("Accept", "application/json", "text/html")
+	m.Put("Host", "example.com")
+
+	expected := []string{"text/html", "application/json", "text/html"}
+	if result := m.Get("Accept"); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+	if m.Len() != 4 || m.KeyCount() != 2 {
+		t.Errorf("Expected 4 values under 2 keys, but got %v under %v", m.Len(), m.KeyCount())
+	}
+
+	if !m.Remove("Accept", "text/html") {
+		t.Errorf("Expected remove to succeed")
+	}
+	expected = []string{"application/json", "text/html"}
+	if result := m.Get("Accept"); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected one occurrence removed, but got %v", result)
+	}
+	if m.Get("Missing") != nil {
+		t.Errorf("Expected nil for a missing key")
+	}
+}
+
+func TestMultiMap_Set(t *testing.T) {
+	m := NewMultiMap[string, int](SetValues)
+	if added := m.PutAll("a", 1, 2, 2, 3); added != 3 {
+		t.Errorf("Expected 3 unique values added, but got %v", added)
+	}
+	if m.Put("a", 1) {
+		t.Errorf("Expected duplicate put to return false")
+	}
+
+	values := m.Get("a")
+	sort.Ints(values)
+	if !reflect.DeepEqual(values, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], but got %v", values)
+	}
+	if !m.ContainsEntry("a", 2) || m.ContainsEntry("a", 4) || m.ContainsEntry("b", 2) {
+		t.Errorf("ContainsEntry returned an unexpected result")
+	}
+}
+
+func TestMultiMap_OrderedSet(t *testing.T) {
+	m := NewMultiMap[string, string](OrderedSetValues)
+	m.PutAll("tags", "go", "db", "go", "cache")
+	m.Put("other", "x")
+
+	if result := m.Get("tags"); !reflect.DeepEqual(result, []string{"go", "db", "cache"}) {
+		t.Errorf("Expected unique values in insertion order, but got %v", result)
+	}
+
+	removed := m.RemoveAll("tags")
+	if !reflect.DeepEqual(removed, []string{"go", "db", "cache"}) {
+		t.Errorf("Expected removed values, but got %v", removed)
+	}
+	if m.ContainsKey("tags") || m.Len() != 1 {
+		t.Errorf("Expected key to be gone after RemoveAll")
+	}
+
+	m.Remove("other", "x")
+	if !m.IsEmpty() || m.KeyCount() != 0 {
+		t.Errorf("Expected key to be dropped with its last value")
+	}
+}
+
+func TestMultiMap_Inverse(t *testing.T) {
+	m := NewMultiMap[string, string](OrderedSetValues)
+	m.PutAll("a", "x", "y")
+	m.PutAll("b", "y", "z")
+
+	inv := m.Inverse()
+	if result := inv.Get("y"); !reflect.DeepEqual(result, []string{"a", "b"}) {
+		t.Errorf("Expected [a b], but got %v", result)
+	}
+	if result := inv.Keys(); !reflect.DeepEqual(result, []string{"x", "y", "z"}) {
+		t.Errorf("Expected [x y z], but got %v", result)
+	}
+	if !m.ContainsValue("z") || m.ContainsValue("w") {
+		t.Errorf("ContainsValue returned an unexpected result")
+	}
+	if inv.Inverse() != m || m.Inverse() != inv {
+		t.Errorf("Expected the inverse of the inverse to be the map itself")
+	}
+
+	// Changes to either side show up in the other.
+	m.Put("c", "x")
+	m.Remove("a", "y")
+	if result := inv.Get("x"); !reflect.DeepEqual(result, []string{"a", "c"}) {
+		t.Errorf("Expected [a c], but got %v", result)
+	}
+	if result := inv.Get("y"); !reflect.DeepEqual(result, []string{"b"}) {
+		t.Errorf("Expected [b], but got %v", result)
+	}
+	inv.Put("w", "a")
+	inv.RemoveAll("z")
+	if result := m.Get("a"); !reflect.DeepEqual(result, []string{"x", "w"}) {
+		t.Errorf("Expected [x w], but got %v", result)
+	}
+	if result := m.Get("b"); !reflect.DeepEqual(result, []string{"y"}) {
+		t.Errorf("Expected [y], but got %v", result)
+	}
+	if m.Len() != inv.Len() || m.Len() != 4 {
+		t.Errorf("Expected both sides to hold 4 entries, but got %v and %v", m.Len(), inv.Len())
+	}
+	m.Clear()
+	if !inv.IsEmpty() || inv.KeyCount() != 0 {
+		t.Errorf("Expected Clear to empty the inverse")
+	}
+}
+
+func TestMultiMap_InverseList(t *testing.T) {
+	m := NewMultiMap[string, int](ListValues)
+	m.PutAll("a", 1, 1, 2)
+	inv := m.Inverse()
+	if result := inv.Get(1); !reflect.DeepEqual(result, []string{"a", "a"}) {
+		t.Errorf("Expected [a a], but got %v", result)
+	}
+
+	m.Remove("a", 1)
+	if result := inv.Get(1); !reflect.DeepEqual(result, []string{"a"}) {
+		t.Errorf("Expected [a], but got %v", result)
+	}
+	if result := m.RemoveAll("a"); !reflect.DeepEqual(result, []int{1, 2}) {
+		t.Errorf("Expected [1 2], but got %v", result)
+	}
+	if !inv.IsEmpty() {
+		t.Errorf("Expected the inverse to be empty, but got %v", inv.Keys())
+	}
+}
+
+func TestMultiMap_FromSlice(t *testing.T) {
+	words := []string{"apple", "avocado", "banana", "blueberry", "cherry"}
+	m := MultiMapFromSlice(words, func(w string) byte { return w[0] }, ListValues)
+
+	if result := m.Get('b'); !reflect.DeepEqual(result, []string{"banana", "blueberry"}) {
+		t.Errorf("Expected [banana blueberry], but got %v", result)
+	}
+	if result := m.Keys(); !reflect.DeepEqual(result, []byte{'a', 'b', 'c'}) {
+		t.Errorf("Expected keys in first-seen order, but got %v", result)
+	}
+
+	lengths := MultiMapFromSliceWith(words, func(w string) int { return len(w) }, strings.ToUpper, SetValues)
+	if !lengths.ContainsEntry(6, "BANANA") || !lengths.ContainsEntry(6, "CHERRY") {
+		t.Errorf("Expected values to be mapped before grouping")
+	}
+
+	var all []string
+	m.Each(func(_ byte, w string) bool {
+		all = append(all, w)
+		return true
+	})
+	if !reflect.DeepEqual(all, m.Values()) || len(all) != 5 {
+		t.Errorf("Expected Each to visit every value in order, but got %v", all)
+	}
+
+	m.Clear()
+	if !m.IsEmpty() {
+		t.Errorf("Expected empty map after Clear")
+	}
+}
@@ -0,0 +1,198 @@
+package collections
+
+import (
+	"sort"
+	"sync"
+)
+
+type EventKind int
+
+const (
+	EventAdded EventKind = iota
+	EventRemoved
+	EventUpdated
+	EventCleared
+)
+
+// String returns the name of the event kind.
+func (k EventKind) String() string {
+	switch k {
+	case EventAdded:
+		return "added"
+	case EventRemoved:
+		return "removed"
+	case EventUpdated:
+		return "updated"
+	case EventCleared:
+		return "cleared"
+	}
+	return "unknown"
+}
+
+// Event describes one change to an observable collection. Key is the element
+// or key that changed. OldValue is set for removals and updates, NewValue for
+// additions and updates. A cleared event carries no key or values.
+type Event[K comparable, V any] struct {
+	Kind     EventKind
+	Key      K
+	OldValue V
+	NewValue V
+}
+
+type Subscription struct {
+	once   sync.Once
+	cancel func()
+}
+
+// Unsubscribe stops the delivery of events to the subscriber. It is safe to
+// call more than once and from any goroutine.
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(s.cancel)
+}
+
+type Observers[K comparable, V any] struct {
+	mu          sync.Mutex
+	next        uint64
+	subscribers map[uint64]func([]Event[K, V])
+	depth       int
+	pending     []Event[K, V]
+}
+
+// Subscribe calls fn with every event, synchronously in the goroutine that
+// made the change and after the change is done.
+//
+// Returns a Subscription that stops the calls.
+func (o *Observers[K, V]) Subscribe(fn func(Event[K, V])) *Subscription {
+	return o.SubscribeBatch(func(events []Event[K, V]) {
+		for _, e := range events {
+			fn(e)
+		}
+	})
+}
+
+// SubscribeBatch calls fn synchronously with the events of each change. A
+// change that affects several elements, such as adding a few at once, or
+// all the changes made inside Batch are delivered in a single call.
+//
+// Returns a Subscription that stops the calls.
+func (o *Observers[K, V]) SubscribeBatch(fn func([]Event[K, V])) *Subscription {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.subscribers == nil {
+		o.subscribers = make(map[uint64]func([]Event[K, V]))
+	}
+	id := o.next
+	o.next++
+	o.subscribers[id] = fn
+	return &Subscription{cancel: func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		delete(o.subscribers, id)
+	}}
+}
+
+// SubscribeChan delivers every event on the returned channel. Events are
+// queued without bound, so a slow reader never blocks changes to the
+// collection. Unsubscribing drops the queued events and closes the channel.
+//
+// Returns the channel and a Subscription that closes it.
+func (o *Observers[K, V]) SubscribeChan() (<-chan Event[K, V], *Subscription) {
+	out := make(chan Event[K, V])
+	signal := make(chan struct{}, 1)
+	done := make(chan struct{})
+	var mu sync.Mutex
+	var queue []Event[K, V]
+
+	sub := o.SubscribeBatch(func(events []Event[K, V]) {
+		mu.Lock()
+		queue = append(queue, events...)
+		mu.Unlock()
+		select {
+		case signal <- struct{}{}:
+		default:
+		}
+	})
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-signal:
+			case <-done:
+				return
+			}
+			mu.Lock()
+			events := queue
+			queue = nil
+			mu.Unlock()
+			for _, e := range events {
+				select {
+				case out <- e:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+
+	return out, &Subscription{cancel: func() {
+		sub.Unsubscribe()
+		close(done)
+	}}
+}
+
+// Batch runs fn and delivers all the events of the changes it makes at once
+// when it returns. Batches can be nested; the events are delivered when the
+// outermost one ends.
+func (o *Observers[K, V]) Batch(fn func()) {
+	o.mu.Lock()
+	o.depth++
+	o.mu.Unlock()
+	defer func() {
+		o.mu.Lock()
+		o.depth--
+		var events []Event[K, V]
+		if o.depth == 0 {
+			events, o.pending = o.pending, nil
+		}
+		o.mu.Unlock()
+		o.deliver(events)
+	}()
+	fn()
+}
+
+// emit delivers events now, or holds them until the current batch ends.
+func (o *Observers[K, V]) emit(events ...Event[K, V]) {
+	if len(events) == 0 {
+		return
+	}
+	o.mu.Lock()
+	if o.depth > 0 {
+		o.pending = append(o.pending, events...)
+		o.mu.Unlock()
+		return
+	}
+	o.mu.Unlock()
+	o.deliver(events)
+}
+
+// deliver calls the subscribers outside the lock, in the order they
+// subscribed, so that they may change the collection or unsubscribe.
+func (o *Observers[K, V]) deliver(events []Event[K, V]) {
+	if len(events) == 0 {
+		return
+	}
+	o.mu.Lock()
+	ids := make([]uint64, 0, len(o.subscribers))
+	for id := range o.subscribers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	fns := make([]func([]Event[K, V]), len(ids))
+	for i, id := range ids {
+		fns[i] = o.subscribers[id]
+	}
+	o.mu.Unlock()
+	for _, fn := range fns {
+		fn(events)
+	}
+}
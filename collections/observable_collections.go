@@ -0,0 +1,302 @@
+package collections
+
+type ObservableSet[T comparable] struct {
+	Observers[T, struct{}]
+	set Set[T]
+}
+
+// NewObservableSet creates a Set that notifies its subscribers of every
+// element added or removed and of Clear.
+//
+// Returns a pointer to the new ObservableSet.
+func NewObservableSet[T comparable](elems ...T) *ObservableSet[T] {
+	return &ObservableSet[T]{set: NewSet(elems...)}
+}
+
+// Add adds elements to the set and emits an added event for each new one.
+func (s *ObservableSet[T]) Add(elems ...T) {
+	var events []Event[T, struct{}]
+	for _, e := range elems {
+		if !s.set.Contains(e) {
+			s.set.Add(e)
+			events = append(events, Event[T, struct{}]{Kind: EventAdded, Key: e})
+		}
+	}
+	s.emit(events...)
+}
+
+// Remove removes elements from the set and emits a removed event for each
+// one that was present.
+func (s *ObservableSet[T]) Remove(elems ...T) {
+	var events []Event[T, struct{}]
+	for _, e := range elems {
+		if s.set.Contains(e) {
+			s.set.Remove(e)
+			events = append(events, Event[T, struct{}]{Kind: EventRemoved, Key: e})
+		}
+	}
+	s.emit(events...)
+}
+
+// Clear removes all elements and emits a cleared event if there were any.
+func (s *ObservableSet[T]) Clear() {
+	if s.set.IsEmpty() {
+		return
+	}
+	s.set = NewSet[T]()
+	s.emit(Event[T, struct{}]{Kind: EventCleared})
+}
+
+// Contains checks if all elements are present in the set.
+func (s *ObservableSet[T]) Contains(elems ...T) bool {
+	return s.set.Contains(elems...)
+}
+
+// ToSlice returns the elements of the set in no particular order.
+func (s *ObservableSet[T]) ToSlice() []T {
+	return s.set.ToSlice()
+}
+
+// Len returns the number of elements in the set.
+func (s *ObservableSet[T]) Len() int {
+	return s.set.Len()
+}
+
+// IsEmpty checks if the set has no elements.
+func (s *ObservableSet[T]) IsEmpty() bool {
+	return s.set.IsEmpty()
+}
+
+// Set returns a copy of the elements as a plain Set.
+func (s *ObservableSet[T]) Set() Set[T] {
+	return s.set.Copy()
+}
+
+type ObservableOrderedSet[T comparable] struct {
+	Observers[T, struct{}]
+	set OrderedSet[T]
+}
+
+// NewObservableOrderedSet creates an OrderedSet that notifies its subscribers
+// of every element added or removed and of Clear.
+//
+// Returns a pointer to the new ObservableOrderedSet.
+func NewObservableOrderedSet[T comparable](elems ...T) *ObservableOrderedSet[T] {
+	return &ObservableOrderedSet[T]{set: NewOrderedSet(elems...)}
+}
+
+// Add appends elements to the set and emits an added event for each new
+// one.
+func (s *ObservableOrderedSet[T]) Add(elems ...T) {
+	var events []Event[T, struct{}]
+	for _, e := range elems {
+		if !s.set.Contains(e) {
+			s.set.Add(e)
+			events = append(events, Event[T, struct{}]{Kind: EventAdded, Key: e})
+		}
+	}
+	s.emit(events...)
+}
+
+// Remove removes elements from the set and emits a removed event for each
+// one that was present.
+func (s *ObservableOrderedSet[T]) Remove(elems ...T) {
+	var events []Event[T, struct{}]
+	for _, e := range elems {
+		if s.set.Contains(e) {
+			s.set.Remove(e)
+			events = append(events, Event[T, struct{}]{Kind: EventRemoved, Key: e})
+		}
+	}
+	s.emit(events...)
+}
+
+// Clear removes all elements and emits a cleared event if there were any.
+func (s *ObservableOrderedSet[T]) Clear() {
+	if s.set.IsEmpty() {
+		return
+	}
+	s.set.Clear()
+	s.emit(Event[T, struct{}]{Kind: EventCleared})
+}
+
+// Contains checks if all elements are present in the set.
+func (s *ObservableOrderedSet[T]) Contains(elems ...T) bool {
+	return s.set.Contains(elems...)
+}
+
+// Get returns the element at index i.
+func (s *ObservableOrderedSet[T]) Get(i int) T {
+	return s.set.Get(i)
+}
+
+// ToSlice returns the elements of the set in insertion order.
+func (s *ObservableOrderedSet[T]) ToSlice() []T {
+	return s.set.ToSlice()
+}
+
+// Len returns the number of elements in the set.
+func (s *ObservableOrderedSet[T]) Len() int {
+	return s.set.Len()
+}
+
+// IsEmpty checks if the set has no elements.
+func (s *ObservableOrderedSet[T]) IsEmpty() bool {
+	return s.set.IsEmpty()
+}
+
+type ObservableOrderedMap[T comparable] struct {
+	Observers[T, interface{}]
+	m *OrderedMap[T]
+}
+
+// NewObservableOrderedMap creates an OrderedMap that notifies its
+// subscribers of every key added, updated or deleted and of Clear.
+//
+// Returns a pointer to the new ObservableOrderedMap.
+func NewObservableOrderedMap[T comparable]() *ObservableOrderedMap[T] {
+	return &ObservableOrderedMap[T]{m: NewOrderedMap[T]()}
+}
+
+// Set adds or updates a key-value pair. It emits an added event for a new
+// key and an updated event with the old and the new value otherwise.
+func (m *ObservableOrderedMap[T]) Set(key T, value interface{}) {
+	old, exists := m.m.Get(key)
+	m.m.Set(key, value)
+	if exists {
+		m.emit(Event[T, interface{}]{Kind: EventUpdated, Key: key, OldValue: old, NewValue: value})
+	} else {
+		m.emit(Event[T, interface{}]{Kind: EventAdded, Key: key, NewValue: value})
+	}
+}
+
+// Delete deletes key and emits a removed event with its value if it was
+// present.
+func (m *ObservableOrderedMap[T]) Delete(key T) {
+	old, exists := m.m.Get(key)
+	if !exists {
+		return
+	}
+	m.m.Delete(key)
+	m.emit(Event[T, interface{}]{Kind: EventRemoved, Key: key, OldValue: old})
+}
+
+// Clear removes all pairs and emits a cleared event if there were any.
+func (m *ObservableOrderedMap[T]) Clear() {
+	if m.m.IsEmpty() {
+		return
+	}
+	m.m.Clear()
+	m.emit(Event[T, interface{}]{Kind: EventCleared})
+}
+
+// Get returns the value for key and whether it exists.
+func (m *ObservableOrderedMap[T]) Get(key T) (interface{}, bool) {
+	return m.m.Get(key)
+}
+
+// Keys returns the keys in insertion order.
+func (m *ObservableOrderedMap[T]) Keys() []T {
+	return m.m.Keys()
+}
+
+// Values returns the values in insertion order of their keys.
+func (m *ObservableOrderedMap[T]) Values() []interface{} {
+	return m.m.Values()
+}
+
+// Len returns the number of pairs in the map.
+func (m *ObservableOrderedMap[T]) Len() int {
+	return m.m.Len()
+}
+
+// IsEmpty checks if the map has no pairs.
+func (m *ObservableOrderedMap[T]) IsEmpty() bool {
+	return m.m.IsEmpty()
+}
+
+type ObservableCounter struct {
+	Observers[interface{}, uint64]
+	counter Counter
+}
+
+// NewObservableCounter creates a Counter that notifies its subscribers of
+// every count that changes and of Clear.
+//
+// Returns a pointer to the new ObservableCounter.
+func NewObservableCounter() *ObservableCounter {
+	return &ObservableCounter{counter: NewCounter()}
+}
+
+// Add increments the count of each element. It emits an added event for an
+// element seen for the first time and an updated event with the old and the
+// new count otherwise.
+func (c *ObservableCounter) Add(elems ...interface{}) {
+	events := make([]Event[interface{}, uint64], 0, len(elems))
+	for _, e := range elems {
+		events = append(events, c.set(e, c.counter.Get(e)+1))
+	}
+	c.emit(events...)
+}
+
+// Set sets the count of elem, emitting an added, updated or removed event
+// when it changes. A count of 0 removes the element.
+func (c *ObservableCounter) Set(elem interface{}, count uint64) {
+	if c.counter.Get(elem) != count {
+		c.emit(c.set(elem, count))
+	}
+}
+
+// Remove deletes elements from the counter and emits a removed event with
+// the count of each one that was present.
+func (c *ObservableCounter) Remove(elems ...interface{}) {
+	var events []Event[interface{}, uint64]
+	for _, e := range elems {
+		if c.counter.Contains(e) {
+			events = append(events, c.set(e, 0))
+		}
+	}
+	c.emit(events...)
+}
+
+// Clear removes all elements and emits a cleared event if there were any.
+func (c *ObservableCounter) Clear() {
+	if c.counter.Len() == 0 {
+		return
+	}
+	c.counter.Clear()
+	c.emit(Event[interface{}, uint64]{Kind: EventCleared})
+}
+
+// Get returns the count of elem.
+func (c *ObservableCounter) Get(elem interface{}) uint64 {
+	return c.counter.Get(elem)
+}
+
+// Contains checks if all elements are present in the counter.
+func (c *ObservableCounter) Contains(elems ...interface{}) bool {
+	return c.counter.Contains(elems...)
+}
+
+// ToSlice returns the elements of the counter.
+func (c *ObservableCounter) ToSlice() []interface{} {
+	return c.counter.ToSlice()
+}
+
+// Len returns the number of distinct elements.
+func (c *ObservableCounter) Len() uint64 {
+	return c.counter.Len()
+}
+
+// set changes the count of elem and returns the event describing it.
+func (c *ObservableCounter) set(elem interface{}, count uint64) Event[interface{}, uint64] {
+	old := c.counter.Get(elem)
+	c.counter.Set(elem, count)
+	switch {
+	case old == 0:
+		return Event[interface{}, uint64]{Kind: EventAdded, Key: elem, NewValue: count}
+	case count == 0:
+		return Event[interface{}, uint64]{Kind: EventRemoved, Key: elem, OldValue: old}
+	}
+	return Event[interface{}, uint64]{Kind: EventUpdated, Key: elem, OldValue: old, NewValue: count}
+}
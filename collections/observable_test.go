@@ -0,0 +1,136 @@
+package collections
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestObservableSet(t *testing.T) {
+	s := NewObservableSet(1)
+	var events []Event[int, struct{}]
+	sub := s.Subscribe(func(e Event[int, struct{}]) { events = append(events, e) })
+
+	s.Add(1, 2, 3)
+	s.Remove(3, 4)
+	s.Clear()
+	s.Clear()
+	expected := []Event[int, struct{}]{
+		{Kind: EventAdded, Key: 2},
+		{Kind: EventAdded, Key: 3},
+		{Kind: EventRemoved, Key: 3},
+		{Kind: EventCleared},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected %v, but got %v", expected, events)
+	}
+
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+	s.Add(5)
+	if len(events) != 4 || !s.Contains(5) {
+		t.Errorf("Expected no events after Unsubscribe")
+	}
+}
+
+func TestObservableOrderedSet_Batch(t *testing.T) {
+	s := NewObservableOrderedSet("a")
+	var batches [][]Event[string, struct{}]
+	s.SubscribeBatch(func(events []Event[string, struct{}]) { batches = append(batches, events) })
+
+	s.Add("b", "c")
+	s.Batch(func() {
+		s.Remove("a")
+		s.Batch(func() { s.Add("d") })
+		if len(batches) != 1 {
+			t.Errorf("Expected events to be held until the batch ends")
+		}
+	})
+	if len(batches) != 2 || len(batches[0]) != 2 || len(batches[1]) != 2 {
+		t.Errorf("Expected two batches of two events, but got %v", batches)
+	}
+	if !reflect.DeepEqual(s.ToSlice(), []string{"b", "c", "d"}) {
+		t.Errorf("Expected [b c d], but got %v", s.ToSlice())
+	}
+}
+
+func TestObservableOrderedMap(t *testing.T) {
+	m := NewObservableOrderedMap[string]()
+	var events []Event[string, interface{}]
+	m.Subscribe(func(e Event[string, interface{}]) { events = append(events, e) })
+
+	m.Set("timeout", 5)
+	m.Set("timeout", 10)
+	m.Delete("timeout")
+	m.Delete("missing")
+	m.Set("retries", 3)
+	m.Clear()
+	expected := []Event[string, interface{}]{
+		{Kind: EventAdded, Key: "timeout", NewValue: 5},
+		{Kind: EventUpdated, Key: "timeout", OldValue: 5, NewValue: 10},
+		{Kind: EventRemoved, Key: "timeout", OldValue: 10},
+		{Kind: EventAdded, Key: "retries", NewValue: 3},
+		{Kind: EventCleared},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected %v, but got %v", expected, events)
+	}
+}
+
+func TestObservableCounter(t *testing.T) {
+	c := NewObservableCounter()
+	var events []Event[interface{}, uint64]
+	c.Subscribe(func(e Event[interface{}, uint64]) { events = append(events, e) })
+
+	c.Add("x", "x")
+	c.Set("x", 2)
+	c.Remove("x", "y")
+	expected := []Event[interface{}, uint64]{
+		{Kind: EventAdded, Key: "x", NewValue: 1},
+		{Kind: EventUpdated, Key: "x", OldValue: 1, NewValue: 2},
+		{Kind: EventRemoved, Key: "x", OldValue: 2},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected %v, but got %v", expected, events)
+	}
+	if c.Len() != 0 {
+		t.Errorf("Expected an empty counter, but got %v", c.Len())
+	}
+}
+
+func TestObservers_SubscribeChan(t *testing.T) {
+	s := NewObservableSet[int]()
+	ch, sub := s.SubscribeChan()
+
+	// The reader is not running yet, so changes must not block.
+	for i := 0; i < 100; i++ {
+		s.Add(i)
+	}
+	for i := 0; i < 100; i++ {
+		select {
+		case e := <-ch:
+			if e.Kind != EventAdded || e.Key != i {
+				t.Fatalf("Expected added %v, but got %v %v", i, e.Kind, e.Key)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for event %v", i)
+		}
+	}
+
+	sub.Unsubscribe()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Errorf("Expected the channel to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Timed out waiting for the channel to close")
+	}
+	s.Add(100)
+}
+
+func TestEventKind_String(t *testing.T) {
+	if EventUpdated.String() != "updated" || EventKind(9).String() != "unknown" {
+		t.Errorf("Unexpected names")
+	}
+}
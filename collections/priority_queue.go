@@ -0,0 +1,213 @@
+package collections
+
+import "cmp"
+
+type PriorityQueue[T any] struct {
+	items []*PriorityQueueItem[T]
+	less  func(a, b T) bool
+}
+
+// PriorityQueueItem is a handle to an element stored in a PriorityQueue.
+//
+// The handle stays valid until the element is popped or removed, and can be
+// passed to Update and Remove to change the element in O(log n).
+type PriorityQueueItem[T any] struct {
+	value T
+	index int
+}
+
+// Value returns the element referenced by the handle.
+func (it *PriorityQueueItem[T]) Value() T {
+	return it.value
+}
+
+// NewPriorityQueue creates an empty priority queue ordered by less.
+//
+// less must return true if a has a higher priority than b, so the element
+// for which less holds against all others is returned first by Pop.
+// Returns a pointer to the new PriorityQueue.
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{items: make([]*PriorityQueueItem[T], 0), less: less}
+}
+
+// NewMinPriorityQueue creates an empty priority queue that pops the smallest
+// element first.
+func NewMinPriorityQueue[T cmp.Ordered]() *PriorityQueue[T] {
+	return NewPriorityQueue(cmp.Less[T])
+}
+
+// NewMaxPriorityQueue creates an empty priority queue that pops the largest
+// element first.
+func NewMaxPriorityQueue[T cmp.Ordered]() *PriorityQueue[T] {
+	return NewPriorityQueue(func(a, b T) bool { return cmp.Less(b, a) })
+}
+
+// PriorityQueueFromSlice builds a priority queue from the given elements.
+//
+// The heap is built bottom-up in O(n). The slice is not modified.
+// Returns a pointer to the new PriorityQueue.
+func PriorityQueueFromSlice[T any](elements []T, less func(a, b T) bool) *PriorityQueue[T] {
+	pq := &PriorityQueue[T]{items: make([]*PriorityQueueItem[T], len(elements)), less: less}
+	for i, e := range elements {
+		pq.items[i] = &PriorityQueueItem[T]{value: e, index: i}
+	}
+	for i := len(pq.items)/2 - 1; i >= 0; i-- {
+		pq.down(i)
+	}
+	return pq
+}
+
+// Push adds an element to the queue.
+//
+// element: the element to be added.
+// Returns a handle that can be used with Update and Remove.
+func (pq *PriorityQueue[T]) Push(element T) *PriorityQueueItem[T] {
+	it := &PriorityQueueItem[T]{value: element, index: len(pq.items)}
+	pq.items = append(pq.items, it)
+	pq.up(it.index)
+	return it
+}
+
+// Pop removes and returns the element with the highest priority.
+//
+// Returns the zero value of T if the queue is empty.
+func (pq *PriorityQueue[T]) Pop() T {
+	if pq.IsEmpty() {
+		var zero T
+		return zero
+	}
+	return pq.removeAt(0).value
+}
+
+// Peek returns the element with the highest priority without removing it.
+//
+// Returns the zero value of T if the queue is empty.
+func (pq *PriorityQueue[T]) Peek() T {
+	if pq.IsEmpty() {
+		var zero T
+		return zero
+	}
+	return pq.items[0].value
+}
+
+// Update replaces the element referenced by the handle and restores the
+// heap order. This is the decrease-key (or increase-key) operation.
+//
+// Returns false if the handle no longer belongs to the queue.
+func (pq *PriorityQueue[T]) Update(it *PriorityQueueItem[T], element T) bool {
+	if !pq.owns(it) {
+		return false
+	}
+	it.value = element
+	pq.fix(it.index)
+	return true
+}
+
+// Remove deletes the element referenced by the handle from the queue.
+//
+// Returns false if the handle no longer belongs to the queue.
+func (pq *PriorityQueue[T]) Remove(it *PriorityQueueItem[T]) bool {
+	if !pq.owns(it) {
+		return false
+	}
+	pq.removeAt(it.index)
+	return true
+}
+
+// Len returns the number of elements in the queue.
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.items)
+}
+
+// IsEmpty checks if the queue is empty.
+//
+// Returns true if the queue has no elements, otherwise false.
+func (pq *PriorityQueue[T]) IsEmpty() bool {
+	return len(pq.items) == 0
+}
+
+// ToSlice returns the elements of the queue in heap order.
+//
+// The order is only guaranteed to start with the element Peek returns.
+func (pq *PriorityQueue[T]) ToSlice() []T {
+	result := make([]T, len(pq.items))
+	for i, it := range pq.items {
+		result[i] = it.value
+	}
+	return result
+}
+
+// Clear removes all elements from the queue. Outstanding handles become
+// invalid.
+func (pq *PriorityQueue[T]) Clear() {
+	for _, it := range pq.items {
+		it.index = -1
+	}
+	pq.items = make([]*PriorityQueueItem[T], 0)
+}
+
+// owns reports whether the handle currently belongs to the queue.
+func (pq *PriorityQueue[T]) owns(it *PriorityQueueItem[T]) bool {
+	return it != nil && it.index >= 0 && it.index < len(pq.items) && pq.items[it.index] == it
+}
+
+// removeAt removes the item at index i and invalidates its handle.
+func (pq *PriorityQueue[T]) removeAt(i int) *PriorityQueueItem[T] {
+	last := len(pq.items) - 1
+	it := pq.items[i]
+	if i != last {
+		pq.swap(i, last)
+	}
+	pq.items[last] = nil
+	pq.items = pq.items[:last]
+	if i != last {
+		pq.fix(i)
+	}
+	it.index = -1
+	return it
+}
+
+func (pq *PriorityQueue[T]) fix(i int) {
+	if !pq.down(i) {
+		pq.up(i)
+	}
+}
+
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.items[i].value, pq.items[parent].value) {
+			break
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// down sifts the item at index i down and reports whether it moved.
+func (pq *PriorityQueue[T]) down(i int) bool {
+	start := i
+	n := len(pq.items)
+	for {
+		left := 2*i + 1
+		if left >= n {
+			break
+		}
+		best := left
+		if right := left + 1; right < n && pq.less(pq.items[right].value, pq.items[left].value) {
+			best = right
+		}
+		if !pq.less(pq.items[best].value, pq.items[i].value) {
+			break
+		}
+		pq.swap(i, best)
+		i = best
+	}
+	return i > start
+}
+
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
@@ -0,0 +1,143 @@
+package collections
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func drain[T any](pq *PriorityQueue[T]) []T {
+	var result []T
+	for !pq.IsEmpty() {
+		result = append(result, pq.Pop())
+	}
+	return result
+}
+
+func TestPriorityQueue_MinOrder(t *testing.T) {
+	pq := NewMinPriorityQueue[int]()
+	for _, e := range []int{5, 3, 8, 1, 9, 2} {
+		pq.Push(e)
+	}
+
+	if pq.Peek() != 1 {
+		t.Errorf("Expected peek 1, but got %v", pq.Peek())
+	}
+
+	expected := []int{1, 2, 3, 5, 8, 9}
+	result := drain(pq)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	if pq.Pop() != 0 {
+		t.Errorf("Expected zero value from empty queue")
+	}
+}
+
+func TestPriorityQueue_MaxOrder(t *testing.T) {
+	pq := NewMaxPriorityQueue[string]()
+	for _, e := range []string{"b", "d", "a", "c"} {
+		pq.Push(e)
+	}
+
+	expected := []string{"d", "c", "b", "a"}
+	result := drain(pq)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestPriorityQueue_Custom(t *testing.T) {
+	type job struct {
+		name     string
+		priority int
+	}
+	pq := NewPriorityQueue(func(a, b job) bool { return a.priority > b.priority })
+	pq.Push(job{"low", 1})
+	pq.Push(job{"high", 10})
+	pq.Push(job{"mid", 5})
+
+	if name := pq.Pop().name; name != "high" {
+		t.Errorf("Expected high, but got %v", name)
+	}
+	if pq.Len() != 2 {
+		t.Errorf("Expected length 2, but got %v", pq.Len())
+	}
+}
+
+func TestPriorityQueue_FromSlice(t *testing.T) {
+	elements := []int{9, 4, 7, 1, 8, 2, 6, 3, 5}
+	pq := PriorityQueueFromSlice(elements, func(a, b int) bool { return a < b })
+
+	expected := append([]int{}, elements...)
+	sort.Ints(expected)
+	result := drain(pq)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	if elements[0] != 9 {
+		t.Errorf("Expected source slice to be left untouched")
+	}
+}
+
+func TestPriorityQueue_Update(t *testing.T) {
+	pq := NewMinPriorityQueue[int]()
+	pq.Push(10)
+	item := pq.Push(20)
+	pq.Push(30)
+
+	if !pq.Update(item, 5) {
+		t.Errorf("Expected update to succeed")
+	}
+	if pq.Peek() != 5 {
+		t.Errorf("Expected peek 5 after decrease-key, but got %v", pq.Peek())
+	}
+
+	pq.Update(item, 40)
+	expected := []int{10, 30, 40}
+	result := drain(pq)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	if pq.Update(item, 1) {
+		t.Errorf("Expected update of popped handle to fail")
+	}
+}
+
+func TestPriorityQueue_Remove(t *testing.T) {
+	pq := NewMinPriorityQueue[int]()
+	handles := make([]*PriorityQueueItem[int], 0)
+	for _, e := range []int{4, 2, 6, 1, 5, 3} {
+		handles = append(handles, pq.Push(e))
+	}
+
+	if !pq.Remove(handles[1]) || !pq.Remove(handles[3]) {
+		t.Errorf("Expected remove to succeed")
+	}
+	if pq.Remove(handles[1]) {
+		t.Errorf("Expected second remove of the same handle to fail")
+	}
+
+	expected := []int{3, 4, 5, 6}
+	result := drain(pq)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestPriorityQueue_Clear(t *testing.T) {
+	pq := NewMinPriorityQueue[int]()
+	item := pq.Push(1)
+	pq.Push(2)
+	pq.Clear()
+
+	if !pq.IsEmpty() {
+		t.Errorf("Expected empty queue after Clear")
+	}
+	if pq.Remove(item) {
+		t.Errorf("Expected handle to be invalid after Clear")
+	}
+}
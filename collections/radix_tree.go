@@ -0,0 +1,443 @@
+package collections
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+type radixNode[V any] struct {
+	prefix   string
+	children []*radixNode[V]
+	value    V
+	hasValue bool
+}
+
+type RadixTree[V any] struct {
+	root  *radixNode[V]
+	size  int
+	runes bool
+}
+
+// NewRadixTree creates an empty radix tree that splits keys on byte
+// boundaries.
+//
+// Returns a pointer to the new RadixTree.
+func NewRadixTree[V any]() *RadixTree[V] {
+	return &RadixTree[V]{root: &radixNode[V]{}}
+}
+
+// NewRuneRadixTree creates an empty radix tree that splits keys only on rune
+// boundaries, so every edge label is a valid UTF-8 string.
+//
+// Keys are expected to be valid UTF-8.
+// Returns a pointer to the new RadixTree.
+func NewRuneRadixTree[V any]() *RadixTree[V] {
+	return &RadixTree[V]{root: &radixNode[V]{}, runes: true}
+}
+
+// Insert adds or updates the value for key.
+//
+// Returns the previous value and true if the key was already present.
+func (t *RadixTree[V]) Insert(key string, value V) (V, bool) {
+	n := t.root
+	search := key
+	for {
+		if search == "" {
+			old, existed := n.value, n.hasValue
+			n.value, n.hasValue = value, true
+			if !existed {
+				t.size++
+			}
+			return old, existed
+		}
+
+		idx, cp := t.findChild(n, search)
+		if idx < 0 {
+			n.addChild(&radixNode[V]{prefix: search, value: value, hasValue: true})
+			t.size++
+			var zero V
+			return zero, false
+		}
+
+		child := n.children[idx]
+		if cp == len(child.prefix) {
+			n = child
+			search = search[cp:]
+			continue
+		}
+
+		// The key diverges inside the edge: split it at the common prefix.
+		mid := &radixNode[V]{prefix: child.prefix[:cp]}
+		child.prefix = child.prefix[cp:]
+		mid.children = []*radixNode[V]{child}
+		n.children[idx] = mid
+
+		search = search[cp:]
+		if search == "" {
+			mid.value, mid.hasValue = value, true
+		} else {
+			mid.addChild(&radixNode[V]{prefix: search, value: value, hasValue: true})
+		}
+		t.size++
+		var zero V
+		return zero, false
+	}
+}
+
+// Get returns the value stored for key and whether the key exists.
+func (t *RadixTree[V]) Get(key string) (V, bool) {
+	n := t.find(key)
+	if n == nil || !n.hasValue {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Contains checks if all the given keys are present in the tree.
+func (t *RadixTree[V]) Contains(keys ...string) bool {
+	for _, k := range keys {
+		if n := t.find(k); n == nil || !n.hasValue {
+			return false
+		}
+	}
+	return true
+}
+
+// Delete removes key from the tree.
+//
+// Returns the removed value and true, or false if the key was not present.
+func (t *RadixTree[V]) Delete(key string) (V, bool) {
+	var zero V
+	var parent *radixNode[V]
+	n := t.root
+	search := key
+	for search != "" {
+		idx, cp := t.findChild(n, search)
+		if idx < 0 || cp < len(n.children[idx].prefix) {
+			return zero, false
+		}
+		parent = n
+		n = n.children[idx]
+		search = search[cp:]
+	}
+	if !n.hasValue {
+		return zero, false
+	}
+
+	old := n.value
+	n.value, n.hasValue = zero, false
+	t.size--
+
+	if n == t.root {
+		return old, true
+	}
+	switch len(n.children) {
+	case 0:
+		parent.removeChild(n)
+		if parent != t.root && !parent.hasValue && len(parent.children) == 1 {
+			parent.mergeChild()
+		}
+	case 1:
+		n.mergeChild()
+	}
+	return old, true
+}
+
+// LongestPrefix finds the longest stored key that is a prefix of s.
+//
+// Returns the key, its value and true, or false if no stored key is a
+// prefix of s.
+func (t *RadixTree[V]) LongestPrefix(s string) (string, V, bool) {
+	var bestKey string
+	var bestValue V
+	found := false
+
+	n := t.root
+	depth := 0
+	for {
+		if n.hasValue {
+			bestKey, bestValue, found = s[:depth], n.value, true
+		}
+		rest := s[depth:]
+		if rest == "" {
+			break
+		}
+		next := n.childWithPrefixOf(rest)
+		if next == nil {
+			break
+		}
+		n = next
+		depth += len(n.prefix)
+	}
+	return bestKey, bestValue, found
+}
+
+// WalkPrefix calls fn for every key that starts with prefix, in
+// lexicographic order, until fn returns false.
+func (t *RadixTree[V]) WalkPrefix(prefix string, fn func(key string, value V) bool) {
+	n := t.root
+	path := ""
+	search := prefix
+	for search != "" {
+		var next *radixNode[V]
+		for _, c := range n.children {
+			if strings.HasPrefix(c.prefix, search) {
+				// Every key below c starts with prefix. In rune mode a
+				// partial-rune prefix can match more than one child.
+				if !walkRadix(c, path+c.prefix, fn) {
+					return
+				}
+				continue
+			}
+			if strings.HasPrefix(search, c.prefix) {
+				next = c
+				break
+			}
+		}
+		if next == nil {
+			return
+		}
+		n = next
+		path += n.prefix
+		search = search[len(n.prefix):]
+	}
+	walkRadix(n, path, fn)
+}
+
+// Walk calls fn for every key in lexicographic order until fn returns false.
+func (t *RadixTree[V]) Walk(fn func(key string, value V) bool) {
+	walkRadix(t.root, "", fn)
+}
+
+// Keys returns all keys in lexicographic order.
+func (t *RadixTree[V]) Keys() []string {
+	keys := make([]string, 0, t.size)
+	t.Walk(func(key string, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns all values in the lexicographic order of their keys.
+func (t *RadixTree[V]) Values() []V {
+	values := make([]V, 0, t.size)
+	t.Walk(func(_ string, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Len returns the number of keys in the tree.
+func (t *RadixTree[V]) Len() int {
+	return t.size
+}
+
+// IsEmpty checks if the tree is empty.
+//
+// Returns true if the tree has no keys, otherwise false.
+func (t *RadixTree[V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear removes all keys from the tree.
+func (t *RadixTree[V]) Clear() {
+	t.root = &radixNode[V]{}
+	t.size = 0
+}
+
+// find returns the node that exactly matches key, or nil.
+func (t *RadixTree[V]) find(key string) *radixNode[V] {
+	n := t.root
+	search := key
+	for search != "" {
+		idx, cp := t.findChild(n, search)
+		if idx < 0 || cp < len(n.children[idx].prefix) {
+			return nil
+		}
+		n = n.children[idx]
+		search = search[cp:]
+	}
+	return n
+}
+
+// findChild returns the index of the child of n that shares a non-empty
+// prefix with search, and the length of that prefix. It returns -1 if there
+// is no such child.
+func (t *RadixTree[V]) findChild(n *radixNode[V], search string) (int, int) {
+	first := search[0]
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix[0] >= first
+	})
+	// In rune mode several children can start with the same byte.
+	for ; i < len(n.children) && n.children[i].prefix[0] == first; i++ {
+		if cp := t.commonPrefix(n.children[i].prefix, search); cp > 0 {
+			return i, cp
+		}
+	}
+	return -1, 0
+}
+
+// commonPrefix returns the length of the longest common prefix of a and b
+// that ends on a split boundary.
+func (t *RadixTree[V]) commonPrefix(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	if t.runes {
+		for n > 0 && !(runeBoundary(a, n) && runeBoundary(b, n)) {
+			n--
+		}
+	}
+	return n
+}
+
+func runeBoundary(s string, i int) bool {
+	return i == len(s) || utf8.RuneStart(s[i])
+}
+
+func (n *radixNode[V]) addChild(child *radixNode[V]) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix >= child.prefix
+	})
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = child
+}
+
+func (n *radixNode[V]) removeChild(child *radixNode[V]) {
+	for i, c := range n.children {
+		if c == child {
+			n.children = append(n.children[:i], n.children[i+1:]...)
+			return
+		}
+	}
+}
+
+// mergeChild folds the only child of n into n.
+func (n *radixNode[V]) mergeChild() {
+	child := n.children[0]
+	n.prefix += child.prefix
+	n.children = child.children
+	n.value, n.hasValue = child.value, child.hasValue
+}
+
+// childWithPrefixOf returns the child whose whole edge label is a prefix of s.
+func (n *radixNode[V]) childWithPrefixOf(s string) *radixNode[V] {
+	for _, c := range n.children {
+		if strings.HasPrefix(s, c.prefix) {
+			return c
+		}
+	}
+	return nil
+}
+
+func walkRadix[V any](n *radixNode[V], path string, fn func(key string, value V) bool) bool {
+	if n.hasValue && !fn(path, n.value) {
+		return false
+	}
+	for _, c := range n.children {
+		if !walkRadix(c, path+c.prefix, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+type RadixSet struct {
+	tree *RadixTree[struct{}]
+}
+
+// NewRadixSet creates a new radix set with the given keys, split on byte
+// boundaries.
+//
+// Returns a RadixSet containing the unique keys.
+func NewRadixSet(keys ...string) RadixSet {
+	s := RadixSet{tree: NewRadixTree[struct{}]()}
+	s.Add(keys...)
+	return s
+}
+
+// NewRuneRadixSet creates a new radix set with the given keys, split on rune
+// boundaries.
+//
+// Returns a RadixSet containing the unique keys.
+func NewRuneRadixSet(keys ...string) RadixSet {
+	s := RadixSet{tree: NewRuneRadixTree[struct{}]()}
+	s.Add(keys...)
+	return s
+}
+
+// Add adds keys to the set.
+func (s RadixSet) Add(keys ...string) {
+	for _, k := range keys {
+		s.tree.Insert(k, struct{}{})
+	}
+}
+
+// Remove deletes the specified keys from the set.
+func (s RadixSet) Remove(keys ...string) {
+	for _, k := range keys {
+		s.tree.Delete(k)
+	}
+}
+
+// Contains checks if all keys are present in the set.
+func (s RadixSet) Contains(keys ...string) bool {
+	return s.tree.Contains(keys...)
+}
+
+// LongestPrefix finds the longest key in the set that is a prefix of str.
+//
+// Returns the key and true, or false if there is none.
+func (s RadixSet) LongestPrefix(str string) (string, bool) {
+	key, _, ok := s.tree.LongestPrefix(str)
+	return key, ok
+}
+
+// WalkPrefix calls fn for every key that starts with prefix, in
+// lexicographic order, until fn returns false.
+func (s RadixSet) WalkPrefix(prefix string, fn func(key string) bool) {
+	s.tree.WalkPrefix(prefix, func(key string, _ struct{}) bool {
+		return fn(key)
+	})
+}
+
+// WithPrefix returns all keys that start with prefix in lexicographic order.
+func (s RadixSet) WithPrefix(prefix string) []string {
+	var keys []string
+	s.WalkPrefix(prefix, func(key string) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// ToSlice returns all keys in lexicographic order.
+func (s RadixSet) ToSlice() []string {
+	return s.tree.Keys()
+}
+
+// ToSet converts the RadixSet to a Set.
+func (s RadixSet) ToSet() Set[string] {
+	return NewSet(s.tree.Keys()...)
+}
+
+// Len returns the number of keys in the set.
+func (s RadixSet) Len() int {
+	return s.tree.Len()
+}
+
+// IsEmpty checks if the set is empty.
+func (s RadixSet) IsEmpty() bool {
+	return s.tree.IsEmpty()
+}
+
+// Clear removes all keys from the set.
+func (s RadixSet) Clear() {
+	s.tree.Clear()
+}
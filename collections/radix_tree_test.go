@@ -0,0 +1,217 @@
+package collections
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRadixTree_InsertGet(t *testing.T) {
+	tree := NewRadixTree[int]()
+	for i, k := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus", ""} {
+		if _, replaced := tree.Insert(k, i); replaced {
+			t.Errorf("Expected %q to be a new key", k)
+		}
+	}
+
+	if old, replaced := tree.Insert("ruber", 100); !replaced || old != 4 {
+		t.Errorf("Expected to replace 4, but got %v %v", old, replaced)
+	}
+	if tree.Len() != 8 {
+		t.Errorf("Expected length 8, but got %v", tree.Len())
+	}
+
+	if v, ok := tree.Get("ruber"); !ok || v != 100 {
+		t.Errorf("Expected 100, but got %v %v", v, ok)
+	}
+	if v, ok := tree.Get(""); !ok || v != 7 {
+		t.Errorf("Expected empty key to be stored, but got %v %v", v, ok)
+	}
+	if _, ok := tree.Get("rom"); ok {
+		t.Errorf("Expected inner prefix not to be a key")
+	}
+	if !tree.Contains("romane", "rubicon") || tree.Contains("romane", "rub") {
+		t.Errorf("Contains returned an unexpected result")
+	}
+}
+
+func TestRadixTree_Delete(t *testing.T) {
+	tree := NewRadixTree[int]()
+	tree.Insert("test", 1)
+	tree.Insert("team", 2)
+	tree.Insert("toast", 3)
+
+	if v, ok := tree.Delete("team"); !ok || v != 2 {
+		t.Errorf("Expected to delete 2, but got %v %v", v, ok)
+	}
+	if _, ok := tree.Delete("team"); ok {
+		t.Errorf("Expected second delete to fail")
+	}
+	if _, ok := tree.Delete("te"); ok {
+		t.Errorf("Expected delete of a non-key prefix to fail")
+	}
+
+	expected := []string{"test", "toast"}
+	if keys := tree.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %v, but got %v", expected, keys)
+	}
+	// The "te" edge must have been merged back into "test".
+	if len(tree.root.children) != 1 || tree.root.children[0].prefix != "t" {
+		t.Errorf("Expected compressed tree after delete")
+	}
+}
+
+func TestRadixTree_WalkPrefix(t *testing.T) {
+	tree := NewRadixTree[int]()
+	for i, k := range []string{"/api/users", "/api/users/1", "/api/orders", "/static/app.js", "/api"} {
+		tree.Insert(k, i)
+	}
+
+	var keys []string
+	tree.WalkPrefix("/api/u", func(k string, _ int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	expected := []string{"/api/users", "/api/users/1"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %v, but got %v", expected, keys)
+	}
+
+	keys = nil
+	tree.WalkPrefix("/api", func(k string, _ int) bool {
+		keys = append(keys, k)
+		return len(keys) < 2
+	})
+	expected = []string{"/api", "/api/orders"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %v, but got %v", expected, keys)
+	}
+
+	keys = nil
+	tree.WalkPrefix("/nope", func(k string, _ int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if len(keys) != 0 {
+		t.Errorf("Expected no keys, but got %v", keys)
+	}
+}
+
+func TestRadixTree_LongestPrefix(t *testing.T) {
+	tree := NewRadixTree[string]()
+	tree.Insert("/", "root")
+	tree.Insert("/api", "api")
+	tree.Insert("/api/v1", "v1")
+
+	cases := map[string]string{
+		"/api/v1/users": "/api/v1",
+		"/api/v2":       "/api",
+		"/index.html":   "/",
+	}
+	for s, expected := range cases {
+		if key, _, ok := tree.LongestPrefix(s); !ok || key != expected {
+			t.Errorf("LongestPrefix(%q): expected %q, but got %q", s, expected, key)
+		}
+	}
+	if _, _, ok := tree.LongestPrefix("api"); ok {
+		t.Errorf("Expected no prefix match")
+	}
+}
+
+func TestRadixTree_RuneMode(t *testing.T) {
+	tree := NewRuneRadixTree[int]()
+	tree.Insert("café", 1)
+	tree.Insert("cafè", 2)
+
+	// é and è share their first UTF-8 byte; in rune mode no edge may split
+	// a rune.
+	var check func(n *radixNode[int])
+	check = func(n *radixNode[int]) {
+		for _, c := range n.children {
+			if !utf8.ValidString(c.prefix) {
+				t.Errorf("Expected valid UTF-8 edge, but got %q", c.prefix)
+			}
+			check(c)
+		}
+	}
+	check(tree.root)
+
+	if v, ok := tree.Get("cafè"); !ok || v != 2 {
+		t.Errorf("Expected 2, but got %v %v", v, ok)
+	}
+	if keys := tree.Keys(); !reflect.DeepEqual(keys, []string{"cafè", "café"}) {
+		t.Errorf("Expected sorted keys, but got %v", keys)
+	}
+
+	var keys []string
+	tree.WalkPrefix("caf\xc3", func(k string, _ int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if len(keys) != 2 {
+		t.Errorf("Expected partial-rune prefix to match both keys, but got %v", keys)
+	}
+}
+
+func TestRadixTree_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewRadixTree[int]()
+	reference := make(map[string]int)
+
+	for i := 0; i < 5000; i++ {
+		k := strconv.FormatInt(rng.Int63n(2000), 36)
+		if rng.Intn(3) == 0 {
+			_, ok1 := tree.Delete(k)
+			_, ok2 := reference[k]
+			delete(reference, k)
+			if ok1 != ok2 {
+				t.Fatalf("Delete(%q): expected %v, but got %v", k, ok2, ok1)
+			}
+		} else {
+			tree.Insert(k, i)
+			reference[k] = i
+		}
+	}
+
+	expected := make([]string, 0, len(reference))
+	for k := range reference {
+		expected = append(expected, k)
+	}
+	sort.Strings(expected)
+	if keys := tree.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("Expected %v keys in order, but got %v", len(expected), len(keys))
+	}
+	for k, v := range reference {
+		if got, ok := tree.Get(k); !ok || got != v {
+			t.Fatalf("Get(%q): expected %v, but got %v", k, v, got)
+		}
+	}
+}
+
+func TestRadixSet(t *testing.T) {
+	s := NewRadixSet("apple", "application", "apply", "banana")
+	s.Add("apple")
+	s.Remove("banana")
+
+	if s.Len() != 3 || s.Contains("banana") {
+		t.Errorf("Expected 3 keys without banana, but got %v", s.ToSlice())
+	}
+
+	expected := []string{"apple", "application", "apply"}
+	if keys := s.WithPrefix("appl"); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %v, but got %v", expected, keys)
+	}
+	if keys := s.WithPrefix("appli"); !reflect.DeepEqual(keys, []string{"application"}) {
+		t.Errorf("Expected [application], but got %v", keys)
+	}
+
+	if key, ok := s.LongestPrefix("apples"); !ok || key != "apple" {
+		t.Errorf("Expected apple, but got %q", key)
+	}
+	if !s.ToSet().Equals(NewSet("apple", "application", "apply")) {
+		t.Errorf("Expected ToSet to contain every key")
+	}
+}
@@ -0,0 +1,279 @@
+package collections
+
+import "sync"
+
+type RingBuffer[T comparable] struct {
+	elements []T
+	head     int
+	size     int
+	onEvict  func(T)
+}
+
+// NewRingBuffer creates an empty ring buffer that holds at most capacity
+// elements. Once it is full, every Push overwrites the oldest element.
+//
+// It panics if capacity is less than 1.
+// Returns a pointer to the new RingBuffer.
+func NewRingBuffer[T comparable](capacity int) *RingBuffer[T] {
+	return NewRingBufferWithEviction[T](capacity, nil)
+}
+
+// NewRingBufferWithEviction creates an empty ring buffer like NewRingBuffer
+// that calls onEvict with every element Push overwrites.
+//
+// Returns a pointer to the new RingBuffer.
+func NewRingBufferWithEviction[T comparable](capacity int, onEvict func(T)) *RingBuffer[T] {
+	if capacity < 1 {
+		panic("collections: RingBuffer capacity must be positive")
+	}
+	return &RingBuffer[T]{elements: make([]T, capacity), onEvict: onEvict}
+}
+
+// Copy returns a new copy of the ring buffer with the same capacity and
+// eviction callback.
+func (r *RingBuffer[T]) Copy() *RingBuffer[T] {
+	c := NewRingBufferWithEviction[T](len(r.elements), r.onEvict)
+	copy(c.elements, r.ToSlice())
+	c.size = r.size
+	return c
+}
+
+// Size returns the number of elements in the ring buffer.
+func (r *RingBuffer[T]) Size() int {
+	return r.size
+}
+
+// Cap returns the maximum number of elements the ring buffer holds.
+func (r *RingBuffer[T]) Cap() int {
+	return len(r.elements)
+}
+
+// IsEmpty checks if the ring buffer is empty.
+func (r *RingBuffer[T]) IsEmpty() bool {
+	return r.size == 0
+}
+
+// IsFull checks if the next Push will overwrite the oldest element.
+func (r *RingBuffer[T]) IsFull() bool {
+	return r.size == len(r.elements)
+}
+
+// Push adds an element as the newest one, overwriting the oldest element if
+// the ring buffer is full.
+//
+// Returns the overwritten element and true, or false if nothing was
+// overwritten.
+func (r *RingBuffer[T]) Push(element T) (T, bool) {
+	var evicted T
+	if r.size < len(r.elements) {
+		r.elements[(r.head+r.size)%len(r.elements)] = element
+		r.size++
+		return evicted, false
+	}
+	evicted = r.elements[r.head]
+	r.elements[r.head] = element
+	r.head = (r.head + 1) % len(r.elements)
+	if r.onEvict != nil {
+		r.onEvict(evicted)
+	}
+	return evicted, true
+}
+
+// Oldest returns the element that was pushed first, or the zero value if the
+// ring buffer is empty.
+func (r *RingBuffer[T]) Oldest() T {
+	if r.size == 0 {
+		var zero T
+		return zero
+	}
+	return r.elements[r.head]
+}
+
+// Newest returns the element that was pushed last, or the zero value if the
+// ring buffer is empty.
+func (r *RingBuffer[T]) Newest() T {
+	if r.size == 0 {
+		var zero T
+		return zero
+	}
+	return r.At(r.size - 1)
+}
+
+// At returns the element at position i, counting from the oldest at 0.
+//
+// It panics if i is out of range.
+func (r *RingBuffer[T]) At(i int) T {
+	if i < 0 || i >= r.size {
+		panic("collections: RingBuffer index out of range")
+	}
+	return r.elements[(r.head+i)%len(r.elements)]
+}
+
+// Each calls fn for every element from the oldest to the newest, until fn
+// returns false.
+func (r *RingBuffer[T]) Each(fn func(i int, element T) bool) {
+	for i := 0; i < r.size; i++ {
+		if !fn(i, r.elements[(r.head+i)%len(r.elements)]) {
+			return
+		}
+	}
+}
+
+// ToSlice returns a slice containing all elements, from the oldest to the
+// newest.
+func (r *RingBuffer[T]) ToSlice() []T {
+	result := make([]T, 0, r.size)
+	first := r.elements[r.head:min(r.head+r.size, len(r.elements))]
+	result = append(result, first...)
+	return append(result, r.elements[:r.size-len(first)]...)
+}
+
+// Snapshot returns the same slice as ToSlice.
+func (r *RingBuffer[T]) Snapshot() []T {
+	return r.ToSlice()
+}
+
+// Equals checks if both ring buffers hold the same elements in the same
+// order. Capacities may differ.
+func (r *RingBuffer[T]) Equals(other *RingBuffer[T]) bool {
+	if r.size != other.size {
+		return false
+	}
+	for i := 0; i < r.size; i++ {
+		if r.At(i) != other.At(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains checks if the ring buffer contains all the specified elements.
+func (r *RingBuffer[T]) Contains(elems ...T) bool {
+	for _, e := range elems {
+		found := false
+		r.Each(func(_ int, element T) bool {
+			found = element == e
+			return !found
+		})
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Clear removes all elements without calling the eviction callback.
+func (r *RingBuffer[T]) Clear() {
+	clear(r.elements)
+	r.head, r.size = 0, 0
+}
+
+type ConcurrentRingBuffer[T comparable] struct {
+	mu      sync.RWMutex
+	buffer  *RingBuffer[T]
+	onEvict func(T)
+}
+
+// NewConcurrentRingBuffer creates an empty ring buffer like NewRingBuffer
+// that is safe for concurrent use.
+//
+// onEvict may be nil. It is called after the lock is released, so it may use
+// the ring buffer itself.
+// Returns a pointer to the new ConcurrentRingBuffer.
+func NewConcurrentRingBuffer[T comparable](capacity int, onEvict func(T)) *ConcurrentRingBuffer[T] {
+	return &ConcurrentRingBuffer[T]{buffer: NewRingBuffer[T](capacity), onEvict: onEvict}
+}
+
+// Push adds an element as the newest one. See RingBuffer.Push.
+func (r *ConcurrentRingBuffer[T]) Push(element T) (T, bool) {
+	r.mu.Lock()
+	evicted, ok := r.buffer.Push(element)
+	r.mu.Unlock()
+	if ok && r.onEvict != nil {
+		r.onEvict(evicted)
+	}
+	return evicted, ok
+}
+
+// Size returns the number of elements in the ring buffer.
+func (r *ConcurrentRingBuffer[T]) Size() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.buffer.Size()
+}
+
+// Cap returns the maximum number of elements the ring buffer holds.
+func (r *ConcurrentRingBuffer[T]) Cap() int {
+	return r.buffer.Cap()
+}
+
+// IsEmpty checks if the ring buffer is empty.
+func (r *ConcurrentRingBuffer[T]) IsEmpty() bool {
+	return r.Size() == 0
+}
+
+// Oldest returns the element that was pushed first, or the zero value if the
+// ring buffer is empty.
+func (r *ConcurrentRingBuffer[T]) Oldest() T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.buffer.Oldest()
+}
+
+// Newest returns the element that was pushed last, or the zero value if the
+// ring buffer is empty.
+func (r *ConcurrentRingBuffer[T]) Newest() T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.buffer.Newest()
+}
+
+// At returns the element at position i, counting from the oldest at 0, and
+// false if i is out of range. Unlike RingBuffer.At it does not panic, since
+// the size may change between calls.
+func (r *ConcurrentRingBuffer[T]) At(i int) (T, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if i < 0 || i >= r.buffer.Size() {
+		var zero T
+		return zero, false
+	}
+	return r.buffer.At(i), true
+}
+
+// Each calls fn for every element of a snapshot, from the oldest to the
+// newest, until fn returns false. The lock is not held while fn runs.
+func (r *ConcurrentRingBuffer[T]) Each(fn func(i int, element T) bool) {
+	for i, e := range r.Snapshot() {
+		if !fn(i, e) {
+			return
+		}
+	}
+}
+
+// Snapshot returns a consistent copy of all elements, from the oldest to the
+// newest.
+func (r *ConcurrentRingBuffer[T]) Snapshot() []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.buffer.ToSlice()
+}
+
+// ToSlice returns the same slice as Snapshot.
+func (r *ConcurrentRingBuffer[T]) ToSlice() []T {
+	return r.Snapshot()
+}
+
+// Contains checks if the ring buffer contains all the specified elements.
+func (r *ConcurrentRingBuffer[T]) Contains(elems ...T) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.buffer.Contains(elems...)
+}
+
+// Clear removes all elements without calling the eviction callback.
+func (r *ConcurrentRingBuffer[T]) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.buffer.Clear()
+}
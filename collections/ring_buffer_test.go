@@ -0,0 +1,109 @@
+package collections
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestRingBuffer_Push(t *testing.T) {
+	r := NewRingBuffer[int](3)
+	if r.Newest() != 0 || r.Oldest() != 0 || !r.IsEmpty() {
+		t.Errorf("Expected zero values from an empty ring buffer")
+	}
+
+	for i := 1; i <= 3; i++ {
+		if _, evicted := r.Push(i); evicted {
+			t.Errorf("Expected no eviction before the buffer is full")
+		}
+	}
+	if evicted, ok := r.Push(4); !ok || evicted != 1 {
+		t.Errorf("Expected 1 to be evicted, but got %v %v", evicted, ok)
+	}
+	r.Push(5)
+
+	expected := []int{3, 4, 5}
+	if result := r.ToSlice(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+	if r.Oldest() != 3 || r.Newest() != 5 || r.At(1) != 4 {
+		t.Errorf("Unexpected oldest %v, newest %v or At(1) %v", r.Oldest(), r.Newest(), r.At(1))
+	}
+	if !r.IsFull() || r.Size() != 3 || r.Cap() != 3 {
+		t.Errorf("Expected a full buffer of size 3")
+	}
+}
+
+func TestRingBuffer_Eviction(t *testing.T) {
+	var evicted []string
+	r := NewRingBufferWithEviction(2, func(s string) { evicted = append(evicted, s) })
+	for _, s := range []string{"a", "b", "c", "d"} {
+		r.Push(s)
+	}
+	if !reflect.DeepEqual(evicted, []string{"a", "b"}) {
+		t.Errorf("Expected [a b] to be evicted, but got %v", evicted)
+	}
+
+	r.Clear()
+	if !r.IsEmpty() || len(evicted) != 2 {
+		t.Errorf("Expected Clear to empty the buffer without evicting")
+	}
+}
+
+func TestRingBuffer_CopyEquals(t *testing.T) {
+	r := NewRingBuffer[int](4)
+	for i := 0; i < 6; i++ {
+		r.Push(i)
+	}
+	c := r.Copy()
+	if !c.Equals(r) || !c.Contains(2, 5) || c.Contains(1) {
+		t.Errorf("Expected copy to equal the original, but got %v", c.ToSlice())
+	}
+
+	c.Push(6)
+	if c.Equals(r) || !reflect.DeepEqual(r.Snapshot(), []int{2, 3, 4, 5}) {
+		t.Errorf("Expected copy to be independent of the original")
+	}
+
+	var seen []int
+	r.Each(func(i, e int) bool {
+		seen = append(seen, e)
+		return i < 1
+	})
+	if !reflect.DeepEqual(seen, []int{2, 3}) {
+		t.Errorf("Expected iteration to stop after two elements, but got %v", seen)
+	}
+}
+
+func TestConcurrentRingBuffer(t *testing.T) {
+	var mu sync.Mutex
+	evicted := 0
+	r := NewConcurrentRingBuffer(100, func(int) {
+		mu.Lock()
+		evicted++
+		mu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				r.Push(w*1000 + i)
+				r.Snapshot()
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if r.Size() != 100 || evicted != 7900 {
+		t.Errorf("Expected 100 elements and 7900 evictions, but got %v and %v", r.Size(), evicted)
+	}
+	if _, ok := r.At(100); ok {
+		t.Errorf("Expected At to fail out of range")
+	}
+	if v, ok := r.At(99); !ok || v != r.Newest() {
+		t.Errorf("Expected At(99) to be the newest element, but got %v %v", v, ok)
+	}
+}
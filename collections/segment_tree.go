@@ -0,0 +1,215 @@
+package collections
+
+type SegmentTree[T Number] struct {
+	n        int
+	tree     []T
+	lazy     []T
+	pending  []bool
+	combine  func(a, b T) T
+	identity T
+	scale    func(delta T, length int) T
+}
+
+// NewSegmentTree creates a segment tree over a copy of values.
+//
+// combine must be associative; identity is the result of a query over an
+// empty range. A tree created this way supports point updates only; use
+// NewLazySegmentTree for range updates.
+// Returns a pointer to the new SegmentTree.
+func NewSegmentTree[T Number](values []T, combine func(a, b T) T, identity T) *SegmentTree[T] {
+	return NewLazySegmentTree(values, combine, identity, nil)
+}
+
+// NewLazySegmentTree creates a segment tree over a copy of values that also
+// supports adding a delta to a whole range in O(log n).
+//
+// scale returns how much the aggregate of length values grows when delta is
+// added to each of them: delta*length for sums, delta for minimums and
+// maximums.
+// Returns a pointer to the new SegmentTree.
+func NewLazySegmentTree[T Number](values []T, combine func(a, b T) T, identity T, scale func(delta T, length int) T) *SegmentTree[T] {
+	n := len(values)
+	s := &SegmentTree[T]{
+		n:        n,
+		tree:     make([]T, 4*max(n, 1)),
+		combine:  combine,
+		identity: identity,
+		scale:    scale,
+	}
+	if scale != nil {
+		s.lazy = make([]T, len(s.tree))
+		s.pending = make([]bool, len(s.tree))
+	}
+	if n > 0 {
+		s.build(values, 1, 0, n)
+	}
+	return s
+}
+
+// NewSumSegmentTree creates a lazy segment tree that answers range sums.
+func NewSumSegmentTree[T Number](values []T) *SegmentTree[T] {
+	return NewLazySegmentTree(values,
+		func(a, b T) T { return a + b },
+		0,
+		func(delta T, length int) T { return delta * T(length) })
+}
+
+// NewMinSegmentTree creates a lazy segment tree that answers range minimums.
+// A query over an empty range returns zero.
+func NewMinSegmentTree[T Number](values []T) *SegmentTree[T] {
+	return NewLazySegmentTree(values,
+		func(a, b T) T { return min(a, b) },
+		0,
+		func(delta T, _ int) T { return delta })
+}
+
+// NewMaxSegmentTree creates a lazy segment tree that answers range maximums.
+// A query over an empty range returns zero.
+func NewMaxSegmentTree[T Number](values []T) *SegmentTree[T] {
+	return NewLazySegmentTree(values,
+		func(a, b T) T { return max(a, b) },
+		0,
+		func(delta T, _ int) T { return delta })
+}
+
+// Query combines the values at indexes in [from, to) in O(log n).
+//
+// Returns the identity for an empty range.
+func (s *SegmentTree[T]) Query(from, to int) T {
+	s.checkRange(from, to)
+	if from == to {
+		return s.identity
+	}
+	result, _ := s.query(1, 0, s.n, from, to)
+	return result
+}
+
+// Get returns the value at index i.
+func (s *SegmentTree[T]) Get(i int) T {
+	return s.Query(i, i+1)
+}
+
+// Set replaces the value at index i in O(log n).
+func (s *SegmentTree[T]) Set(i int, value T) {
+	s.checkRange(i, i+1)
+	s.set(1, 0, s.n, i, value)
+}
+
+// AddRange adds delta to every value at indexes in [from, to) in O(log n).
+//
+// It panics if the tree was not created with NewLazySegmentTree.
+func (s *SegmentTree[T]) AddRange(from, to int, delta T) {
+	if s.scale == nil {
+		panic("collections: AddRange needs a lazy segment tree")
+	}
+	s.checkRange(from, to)
+	if from < to {
+		s.add(1, 0, s.n, from, to, delta)
+	}
+}
+
+// Len returns the number of values.
+func (s *SegmentTree[T]) Len() int {
+	return s.n
+}
+
+// ToSlice returns the current values.
+func (s *SegmentTree[T]) ToSlice() []T {
+	values := make([]T, s.n)
+	for i := range values {
+		values[i] = s.Get(i)
+	}
+	return values
+}
+
+func (s *SegmentTree[T]) checkRange(from, to int) {
+	if from < 0 || to > s.n || from > to {
+		panic("collections: SegmentTree range out of bounds")
+	}
+}
+
+func (s *SegmentTree[T]) build(values []T, node, l, r int) {
+	if r-l == 1 {
+		s.tree[node] = values[l]
+		return
+	}
+	m := (l + r) / 2
+	s.build(values, 2*node, l, m)
+	s.build(values, 2*node+1, m, r)
+	s.tree[node] = s.combine(s.tree[2*node], s.tree[2*node+1])
+}
+
+// query returns the aggregate of the part of [from, to) inside [l, r), and
+// false if they do not overlap. The identity is never combined in, so the
+// Min and Max trees do not need a sentinel value.
+func (s *SegmentTree[T]) query(node, l, r, from, to int) (T, bool) {
+	if to <= l || r <= from {
+		return s.identity, false
+	}
+	if from <= l && r <= to {
+		return s.tree[node], true
+	}
+	s.push(node, l, r)
+	m := (l + r) / 2
+	left, okL := s.query(2*node, l, m, from, to)
+	right, okR := s.query(2*node+1, m, r, from, to)
+	switch {
+	case okL && okR:
+		return s.combine(left, right), true
+	case okL:
+		return left, true
+	default:
+		return right, okR
+	}
+}
+
+func (s *SegmentTree[T]) set(node, l, r, i int, value T) {
+	if r-l == 1 {
+		s.tree[node] = value
+		return
+	}
+	s.push(node, l, r)
+	m := (l + r) / 2
+	if i < m {
+		s.set(2*node, l, m, i, value)
+	} else {
+		s.set(2*node+1, m, r, i, value)
+	}
+	s.tree[node] = s.combine(s.tree[2*node], s.tree[2*node+1])
+}
+
+func (s *SegmentTree[T]) add(node, l, r, from, to int, delta T) {
+	if to <= l || r <= from {
+		return
+	}
+	if from <= l && r <= to {
+		s.apply(node, l, r, delta)
+		return
+	}
+	s.push(node, l, r)
+	m := (l + r) / 2
+	s.add(2*node, l, m, from, to, delta)
+	s.add(2*node+1, m, r, from, to, delta)
+	s.tree[node] = s.combine(s.tree[2*node], s.tree[2*node+1])
+}
+
+// apply adds delta to every value under node, deferring the children.
+func (s *SegmentTree[T]) apply(node, l, r int, delta T) {
+	s.tree[node] += s.scale(delta, r-l)
+	if r-l > 1 {
+		s.lazy[node] += delta
+		s.pending[node] = true
+	}
+}
+
+// push hands a deferred delta of node down to its children.
+func (s *SegmentTree[T]) push(node, l, r int) {
+	if s.pending == nil || !s.pending[node] {
+		return
+	}
+	m := (l + r) / 2
+	s.apply(2*node, l, m, s.lazy[node])
+	s.apply(2*node+1, m, r, s.lazy[node])
+	s.lazy[node] = 0
+	s.pending[node] = false
+}
@@ -0,0 +1,91 @@
+package collections
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSegmentTree_MinMax(t *testing.T) {
+	values := []int{5, 2, 8, 1, 9, 3}
+	minTree := NewMinSegmentTree(values)
+	maxTree := NewMaxSegmentTree(values)
+
+	if minTree.Query(0, 3) != 2 || maxTree.Query(2, 6) != 9 {
+		t.Errorf("Unexpected min %v or max %v", minTree.Query(0, 3), maxTree.Query(2, 6))
+	}
+
+	minTree.AddRange(1, 4, 10)
+	maxTree.AddRange(1, 4, 10)
+	if minTree.Query(0, 6) != 3 || maxTree.Query(0, 4) != 18 {
+		t.Errorf("Unexpected min %v or max %v after range add", minTree.Query(0, 6), maxTree.Query(0, 4))
+	}
+
+	minTree.Set(5, -4)
+	if minTree.Query(4, 6) != -4 || minTree.Query(3, 3) != 0 {
+		t.Errorf("Unexpected results after Set: %v", minTree.ToSlice())
+	}
+}
+
+func TestSegmentTree_Custom(t *testing.T) {
+	gcd := func(a, b uint) uint {
+		for b != 0 {
+			a, b = b, a%b
+		}
+		return a
+	}
+	s := NewSegmentTree([]uint{12, 18, 24, 7}, gcd, 0)
+	if s.Query(0, 3) != 6 || s.Query(0, 4) != 1 {
+		t.Errorf("Unexpected gcd results %v %v", s.Query(0, 3), s.Query(0, 4))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected AddRange to panic on a non-lazy tree")
+		}
+	}()
+	s.AddRange(0, 1, 1)
+}
+
+func TestSegmentTree_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	reference := make([]int64, 150)
+	for i := range reference {
+		reference[i] = int64(rng.Intn(1000))
+	}
+	sum := NewSumSegmentTree(reference)
+	minTree := NewMinSegmentTree(reference)
+
+	for k := 0; k < 2000; k++ {
+		from := rng.Intn(150)
+		to := from + 1 + rng.Intn(150-from)
+		switch rng.Intn(3) {
+		case 0:
+			delta := int64(rng.Intn(200) - 100)
+			sum.AddRange(from, to, delta)
+			minTree.AddRange(from, to, delta)
+			for i := from; i < to; i++ {
+				reference[i] += delta
+			}
+		case 1:
+			v := int64(rng.Intn(1000))
+			sum.Set(from, v)
+			minTree.Set(from, v)
+			reference[from] = v
+		default:
+			var expectedSum int64
+			expectedMin := reference[from]
+			for _, v := range reference[from:to] {
+				expectedSum += v
+				expectedMin = min(expectedMin, v)
+			}
+			if sum.Query(from, to) != expectedSum || minTree.Query(from, to) != expectedMin {
+				t.Fatalf("Query(%v, %v): expected %v/%v, but got %v/%v", from, to,
+					expectedSum, expectedMin, sum.Query(from, to), minTree.Query(from, to))
+			}
+		}
+	}
+	if !reflect.DeepEqual(sum.ToSlice(), reference) {
+		t.Errorf("Expected values to match the reference")
+	}
+}
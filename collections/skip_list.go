@@ -0,0 +1,379 @@
+package collections
+
+import (
+	"cmp"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+)
+
+const skipListMaxLevel = 32
+
+type skipListNode[K, V any] struct {
+	key     K
+	value   atomic.Pointer[V]
+	deleted atomic.Bool
+	next    []atomic.Pointer[skipListNode[K, V]]
+}
+
+type SkipList[K, V any] struct {
+	head  *skipListNode[K, V]
+	level atomic.Int32
+	size  atomic.Int64
+	less  func(a, b K) bool
+	mu    sync.Mutex
+}
+
+// NewSkipList creates an empty skip list ordered by less.
+//
+// less must return true if a sorts before b, as for NewPriorityQueue. Keys
+// for which neither less(a, b) nor less(b, a) holds are considered equal.
+//
+// Reads (Get, Floor, Ceiling, iteration and so on) never block and can run
+// from any number of goroutines while another goroutine writes. Writes are
+// serialized by an internal mutex.
+// Returns a pointer to the new SkipList.
+func NewSkipList[K, V any](less func(a, b K) bool) *SkipList[K, V] {
+	s := &SkipList[K, V]{
+		head: &skipListNode[K, V]{next: make([]atomic.Pointer[skipListNode[K, V]], skipListMaxLevel)},
+		less: less,
+	}
+	s.level.Store(1)
+	return s
+}
+
+// NewOrderedSkipList creates an empty skip list ordered by the natural order
+// of K.
+func NewOrderedSkipList[K cmp.Ordered, V any]() *SkipList[K, V] {
+	return NewSkipList[K, V](cmp.Less[K])
+}
+
+// Put adds or updates the value for key.
+//
+// Returns true if the key was not present before.
+func (s *SkipList[K, V]) Put(key K, value V) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var preds [skipListMaxLevel]*skipListNode[K, V]
+	s.findPredecessors(key, &preds)
+	if n := preds[0].next[0].Load(); n != nil && s.equal(n.key, key) {
+		n.value.Store(&value)
+		return false
+	}
+
+	height := randomSkipListLevel()
+	if level := int(s.level.Load()); height > level {
+		for i := level; i < height; i++ {
+			preds[i] = s.head
+		}
+		s.level.Store(int32(height))
+	}
+
+	n := &skipListNode[K, V]{key: key, next: make([]atomic.Pointer[skipListNode[K, V]], height)}
+	n.value.Store(&value)
+	// Link the node fully before publishing it, bottom-up, so a reader that
+	// reaches it at any level can always continue from it.
+	for i := 0; i < height; i++ {
+		n.next[i].Store(preds[i].next[i].Load())
+	}
+	for i := 0; i < height; i++ {
+		preds[i].next[i].Store(n)
+	}
+	s.size.Add(1)
+	return true
+}
+
+// Get returns the value stored for key and whether the key exists.
+func (s *SkipList[K, V]) Get(key K) (V, bool) {
+	if n := s.seek(key); n != nil && s.equal(n.key, key) {
+		return *n.value.Load(), true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains checks if all the given keys are present in the skip list.
+func (s *SkipList[K, V]) Contains(keys ...K) bool {
+	for _, k := range keys {
+		if _, ok := s.Get(k); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Delete removes key from the skip list.
+//
+// Returns the removed value and true, or false if the key was not present.
+// Readers that are positioned on the removed node can still move past it.
+func (s *SkipList[K, V]) Delete(key K) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var preds [skipListMaxLevel]*skipListNode[K, V]
+	s.findPredecessors(key, &preds)
+	n := preds[0].next[0].Load()
+	if n == nil || !s.equal(n.key, key) {
+		var zero V
+		return zero, false
+	}
+
+	n.deleted.Store(true)
+	for i := len(n.next) - 1; i >= 0; i-- {
+		preds[i].next[i].Store(n.next[i].Load())
+	}
+	s.size.Add(-1)
+	return *n.value.Load(), true
+}
+
+// Floor returns the greatest key less than or equal to key.
+//
+// Returns false if there is no such key.
+func (s *SkipList[K, V]) Floor(key K) (K, V, bool) {
+	return nodeEntry(s.floor(key))
+}
+
+// Ceiling returns the least key greater than or equal to key.
+//
+// Returns false if there is no such key.
+func (s *SkipList[K, V]) Ceiling(key K) (K, V, bool) {
+	return nodeEntry(s.seek(key))
+}
+
+// First returns the smallest key.
+//
+// Returns false if the skip list is empty.
+func (s *SkipList[K, V]) First() (K, V, bool) {
+	return nodeEntry(s.live(s.head.next[0].Load()))
+}
+
+// Last returns the greatest key.
+//
+// Returns false if the skip list is empty.
+func (s *SkipList[K, V]) Last() (K, V, bool) {
+	x := s.head
+	for i := int(s.level.Load()) - 1; i > 0; i-- {
+		for n := x.next[i].Load(); n != nil; n = x.next[i].Load() {
+			if n.deleted.Load() {
+				break
+			}
+			x = n
+		}
+	}
+	var last *skipListNode[K, V]
+	if !x.deleted.Load() && x != s.head {
+		last = x
+	}
+	for n := x.next[0].Load(); n != nil; n = n.next[0].Load() {
+		if !n.deleted.Load() {
+			last = n
+		}
+	}
+	return nodeEntry(last)
+}
+
+// Range calls fn for every key in [from, to) in ascending order until fn
+// returns false.
+func (s *SkipList[K, V]) Range(from, to K, fn func(key K, value V) bool) {
+	for n := s.seek(from); n != nil && s.less(n.key, to); n = s.live(n.next[0].Load()) {
+		if !fn(n.key, *n.value.Load()) {
+			return
+		}
+	}
+}
+
+// Each calls fn for every key in ascending order until fn returns false.
+func (s *SkipList[K, V]) Each(fn func(key K, value V) bool) {
+	for n := s.live(s.head.next[0].Load()); n != nil; n = s.live(n.next[0].Load()) {
+		if !fn(n.key, *n.value.Load()) {
+			return
+		}
+	}
+}
+
+// Keys returns all keys in ascending order.
+func (s *SkipList[K, V]) Keys() []K {
+	keys := make([]K, 0, s.Len())
+	s.Each(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns all values in ascending order of their keys.
+func (s *SkipList[K, V]) Values() []V {
+	values := make([]V, 0, s.Len())
+	s.Each(func(_ K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Len returns the number of keys in the skip list.
+func (s *SkipList[K, V]) Len() int {
+	return int(s.size.Load())
+}
+
+// IsEmpty checks if the skip list is empty.
+//
+// Returns true if the skip list has no keys, otherwise false.
+func (s *SkipList[K, V]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Clear removes all keys from the skip list.
+func (s *SkipList[K, V]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for n := s.head.next[0].Load(); n != nil; n = n.next[0].Load() {
+		n.deleted.Store(true)
+	}
+	for i := range s.head.next {
+		s.head.next[i].Store(nil)
+	}
+	s.level.Store(1)
+	s.size.Store(0)
+}
+
+// Iterator returns an unpositioned iterator over the skip list. Call Seek,
+// SeekFirst or SeekFloor before reading from it.
+func (s *SkipList[K, V]) Iterator() *SkipListIterator[K, V] {
+	return &SkipListIterator[K, V]{list: s}
+}
+
+type SkipListIterator[K, V any] struct {
+	list *SkipList[K, V]
+	node *skipListNode[K, V]
+}
+
+// Seek positions the iterator at the first key greater than or equal to key.
+//
+// Returns false if there is no such key.
+func (it *SkipListIterator[K, V]) Seek(key K) bool {
+	it.node = it.list.seek(key)
+	return it.node != nil
+}
+
+// SeekFirst positions the iterator at the smallest key.
+//
+// Returns false if the skip list is empty.
+func (it *SkipListIterator[K, V]) SeekFirst() bool {
+	it.node = it.list.live(it.list.head.next[0].Load())
+	return it.node != nil
+}
+
+// SeekFloor positions the iterator at the greatest key less than or equal
+// to key.
+//
+// Returns false if there is no such key.
+func (it *SkipListIterator[K, V]) SeekFloor(key K) bool {
+	it.node = it.list.floor(key)
+	return it.node != nil
+}
+
+// Next advances the iterator to the next key.
+//
+// Returns false when the iterator moves past the last key.
+func (it *SkipListIterator[K, V]) Next() bool {
+	if it.node != nil {
+		it.node = it.list.live(it.node.next[0].Load())
+	}
+	return it.node != nil
+}
+
+// Valid reports whether the iterator is positioned at a key.
+func (it *SkipListIterator[K, V]) Valid() bool {
+	return it.node != nil
+}
+
+// Key returns the key at the current position.
+//
+// It panics if the iterator is not valid.
+func (it *SkipListIterator[K, V]) Key() K {
+	return it.node.key
+}
+
+// Value returns the value at the current position.
+//
+// It panics if the iterator is not valid.
+func (it *SkipListIterator[K, V]) Value() V {
+	return *it.node.value.Load()
+}
+
+func (s *SkipList[K, V]) equal(a, b K) bool {
+	return !s.less(a, b) && !s.less(b, a)
+}
+
+// findPredecessors fills preds with the last node before key on every level.
+// It must be called with s.mu held.
+func (s *SkipList[K, V]) findPredecessors(key K, preds *[skipListMaxLevel]*skipListNode[K, V]) {
+	x := s.head
+	for i := int(s.level.Load()) - 1; i >= 0; i-- {
+		for n := x.next[i].Load(); n != nil && s.less(n.key, key); n = x.next[i].Load() {
+			x = n
+		}
+		preds[i] = x
+	}
+}
+
+// seek returns the first live node with a key greater than or equal to key.
+func (s *SkipList[K, V]) seek(key K) *skipListNode[K, V] {
+	x := s.head
+	for i := int(s.level.Load()) - 1; i >= 0; i-- {
+		for n := x.next[i].Load(); n != nil && s.less(n.key, key); n = x.next[i].Load() {
+			x = n
+		}
+	}
+	return s.live(x.next[0].Load())
+}
+
+// floor returns the last live node with a key less than or equal to key.
+func (s *SkipList[K, V]) floor(key K) *skipListNode[K, V] {
+	// Upper levels never step onto deleted nodes, so x is always a node that
+	// was live when it was reached; level 0 then walks to the answer.
+	x := s.head
+	for i := int(s.level.Load()) - 1; i > 0; i-- {
+		for n := x.next[i].Load(); n != nil && !n.deleted.Load() && !s.less(key, n.key); n = x.next[i].Load() {
+			x = n
+		}
+	}
+	var last *skipListNode[K, V]
+	if x != s.head {
+		last = x
+	}
+	for n := x.next[0].Load(); n != nil && !s.less(key, n.key); n = n.next[0].Load() {
+		if !n.deleted.Load() {
+			last = n
+		}
+	}
+	return last
+}
+
+// live returns n, or the first node after it that has not been deleted.
+func (s *SkipList[K, V]) live(n *skipListNode[K, V]) *skipListNode[K, V] {
+	for n != nil && n.deleted.Load() {
+		n = n.next[0].Load()
+	}
+	return n
+}
+
+func nodeEntry[K, V any](n *skipListNode[K, V]) (K, V, bool) {
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return n.key, *n.value.Load(), true
+}
+
+// randomSkipListLevel returns a node height with P(h > k) = 1/4^k.
+func randomSkipListLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.Intn(4) == 0 {
+		level++
+	}
+	return level
+}
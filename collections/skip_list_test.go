@@ -0,0 +1,187 @@
+package collections
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestSkipList_PutGetDelete(t *testing.T) {
+	s := NewOrderedSkipList[int, string]()
+	if !s.Put(2, "two") || !s.Put(1, "one") || !s.Put(3, "three") {
+		t.Errorf("Expected new keys to be reported as added")
+	}
+	if s.Put(2, "TWO") {
+		t.Errorf("Expected update of existing key to return false")
+	}
+
+	if v, ok := s.Get(2); !ok || v != "TWO" {
+		t.Errorf("Expected TWO, but got %v %v", v, ok)
+	}
+	if s.Len() != 3 {
+		t.Errorf("Expected length 3, but got %v", s.Len())
+	}
+
+	if v, ok := s.Delete(1); !ok || v != "one" {
+		t.Errorf("Expected to delete one, but got %v %v", v, ok)
+	}
+	if _, ok := s.Delete(1); ok {
+		t.Errorf("Expected second delete to fail")
+	}
+	if s.Contains(1) || !s.Contains(2, 3) {
+		t.Errorf("Contains returned an unexpected result")
+	}
+
+	expected := []int{2, 3}
+	if keys := s.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %v, but got %v", expected, keys)
+	}
+}
+
+func TestSkipList_FloorCeiling(t *testing.T) {
+	s := NewOrderedSkipList[int, int]()
+	for _, k := range []int{10, 20, 30, 40} {
+		s.Put(k, k*10)
+	}
+
+	if k, v, ok := s.Floor(25); !ok || k != 20 || v != 200 {
+		t.Errorf("Floor(25): expected 20, but got %v %v", k, ok)
+	}
+	if k, _, ok := s.Floor(30); !ok || k != 30 {
+		t.Errorf("Floor(30): expected 30, but got %v %v", k, ok)
+	}
+	if _, _, ok := s.Floor(5); ok {
+		t.Errorf("Floor(5): expected no key")
+	}
+	if k, _, ok := s.Ceiling(25); !ok || k != 30 {
+		t.Errorf("Ceiling(25): expected 30, but got %v %v", k, ok)
+	}
+	if _, _, ok := s.Ceiling(41); ok {
+		t.Errorf("Ceiling(41): expected no key")
+	}
+	if k, _, _ := s.First(); k != 10 {
+		t.Errorf("Expected first 10, but got %v", k)
+	}
+	if k, _, _ := s.Last(); k != 40 {
+		t.Errorf("Expected last 40, but got %v", k)
+	}
+}
+
+func TestSkipList_RangeAndIterator(t *testing.T) {
+	s := NewSkipList[string, int](func(a, b string) bool { return a > b })
+	for i, k := range []string{"a", "b", "c", "d", "e"} {
+		s.Put(k, i)
+	}
+
+	var keys []string
+	s.Range("d", "a", func(k string, _ int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	expected := []string{"d", "c", "b"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %v, but got %v", expected, keys)
+	}
+
+	it := s.Iterator()
+	keys = nil
+	for ok := it.Seek("c"); ok; ok = it.Next() {
+		keys = append(keys, it.Key())
+	}
+	expected = []string{"c", "b", "a"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %v, but got %v", expected, keys)
+	}
+
+	if !it.SeekFloor("bb") || it.Key() != "c" || it.Value() != 2 {
+		t.Errorf("Expected floor of bb in descending order to be c")
+	}
+	if !it.SeekFirst() || it.Key() != "e" {
+		t.Errorf("Expected first key e")
+	}
+}
+
+func TestSkipList_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	s := NewOrderedSkipList[int, int]()
+	reference := make(map[int]int)
+
+	for i := 0; i < 5000; i++ {
+		k := rng.Intn(1000)
+		if rng.Intn(3) == 0 {
+			s.Delete(k)
+			delete(reference, k)
+		} else {
+			s.Put(k, i)
+			reference[k] = i
+		}
+	}
+
+	expected := make([]int, 0, len(reference))
+	for k := range reference {
+		expected = append(expected, k)
+	}
+	sort.Ints(expected)
+	if keys := s.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("Expected %v keys in order, but got %v", len(expected), len(keys))
+	}
+	if s.Len() != len(reference) {
+		t.Errorf("Expected length %v, but got %v", len(reference), s.Len())
+	}
+
+	s.Clear()
+	if !s.IsEmpty() || len(s.Keys()) != 0 {
+		t.Errorf("Expected empty skip list after Clear")
+	}
+}
+
+func TestSkipList_ConcurrentReaders(t *testing.T) {
+	s := NewOrderedSkipList[int, int]()
+	for k := 0; k < 1000; k += 2 {
+		s.Put(k, k)
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				// Even keys are never touched by the writer.
+				for k := 0; k < 1000; k += 100 {
+					if v, ok := s.Get(k); !ok || v != k {
+						t.Errorf("Expected stable key %v, but got %v %v", k, v, ok)
+						return
+					}
+				}
+				prev := -1
+				s.Each(func(k, _ int) bool {
+					if k <= prev {
+						t.Errorf("Expected ascending keys, got %v after %v", k, prev)
+					}
+					prev = k
+					return true
+				})
+			}
+		}()
+	}
+
+	for i := 0; i < 2000; i++ {
+		k := 2*(i%500) + 1
+		if i%2 == 0 {
+			s.Put(k, k)
+		} else {
+			s.Delete(k)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
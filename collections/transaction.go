@@ -0,0 +1,464 @@
+package collections
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrTxConflict is returned by Commit under SnapshotIsolation when another
+	// transaction committed a change to a key this one changed.
+	ErrTxConflict = errors.New("collections: transaction conflicts with a concurrent commit")
+	// ErrTxDone is returned by Commit when the transaction was already
+	// committed or rolled back.
+	ErrTxDone = errors.New("collections: transaction already finished")
+)
+
+type Isolation int
+
+const (
+	// ReadCommitted transactions read the latest committed state plus their
+	// own writes, and their commits never conflict: the last commit to a key
+	// wins.
+	ReadCommitted Isolation = iota
+	// SnapshotIsolation transactions read the state as of Begin plus their
+	// own writes, and a commit fails with ErrTxConflict if another
+	// transaction committed a key it wrote after it began.
+	SnapshotIsolation
+)
+
+// txLog tracks the version of the committed state and the version each key
+// was last committed in, and serializes commits.
+type txLog[T comparable] struct {
+	mu       sync.Mutex
+	version  uint64
+	versions map[T]uint64
+}
+
+// conflicts checks if any of keys was committed after version. It must be
+// called with mu held.
+func (l *txLog[T]) conflicts(version uint64, keys []T) bool {
+	for _, k := range keys {
+		if l.versions[k] > version {
+			return true
+		}
+	}
+	return false
+}
+
+// record marks keys as committed in a new version. It must be called with mu
+// held.
+func (l *txLog[T]) record(keys []T) {
+	l.version++
+	for _, k := range keys {
+		l.versions[k] = l.version
+	}
+}
+
+type TxOrderedMap[T comparable] struct {
+	isolation Isolation
+	log       txLog[T]
+	committed atomic.Pointer[OrderedMap[T]]
+}
+
+type txWrite struct {
+	value   interface{}
+	deleted bool
+}
+
+type txOp[T comparable] struct {
+	key T
+	txWrite
+}
+
+type OrderedMapTx[T comparable] struct {
+	m       *TxOrderedMap[T]
+	base    *OrderedMap[T]
+	version uint64
+	writes  map[T]txWrite
+	ops     []txOp[T]
+	done    bool
+}
+
+// NewTxOrderedMap creates an empty OrderedMap that is changed through
+// transactions. Every commit publishes a new copy of the map, so readers
+// never block and always see a committed state.
+//
+// Returns a pointer to the new TxOrderedMap.
+func NewTxOrderedMap[T comparable](isolation Isolation) *TxOrderedMap[T] {
+	m := &TxOrderedMap[T]{isolation: isolation, log: txLog[T]{versions: make(map[T]uint64)}}
+	m.committed.Store(NewOrderedMap[T]())
+	return m
+}
+
+// Begin starts a transaction. Its writes are recorded on the side and only
+// become visible to others when it commits.
+//
+// Returns a pointer to the new OrderedMapTx.
+func (m *TxOrderedMap[T]) Begin() *OrderedMapTx[T] {
+	m.log.mu.Lock()
+	defer m.log.mu.Unlock()
+	return &OrderedMapTx[T]{m: m, base: m.committed.Load(), version: m.log.version, writes: make(map[T]txWrite)}
+}
+
+// Update runs fn in a new transaction and commits it if fn returns nil. The
+// transaction is rolled back if fn returns an error or panics.
+//
+// Returns the error of fn or of Commit.
+func (m *TxOrderedMap[T]) Update(fn func(tx *OrderedMapTx[T]) error) error {
+	tx := m.Begin()
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Snapshot returns a copy of the committed map.
+func (m *TxOrderedMap[T]) Snapshot() *OrderedMap[T] {
+	return copyOrderedMap(m.committed.Load())
+}
+
+// Get returns the committed value for key and whether it exists.
+func (m *TxOrderedMap[T]) Get(key T) (interface{}, bool) {
+	return m.committed.Load().Get(key)
+}
+
+// Keys returns the committed keys in insertion order.
+func (m *TxOrderedMap[T]) Keys() []T {
+	return m.committed.Load().Keys()
+}
+
+// Len returns the number of committed pairs.
+func (m *TxOrderedMap[T]) Len() int {
+	return m.committed.Load().Len()
+}
+
+// Set records a write of value for key.
+//
+// It panics if the transaction has finished.
+func (tx *OrderedMapTx[T]) Set(key T, value interface{}) {
+	tx.write(key, value, false)
+}
+
+// Delete records the deletion of key.
+//
+// It panics if the transaction has finished.
+func (tx *OrderedMapTx[T]) Delete(key T) {
+	tx.write(key, nil, true)
+}
+
+// Get returns the value for key as seen by the transaction, including its
+// own writes.
+func (tx *OrderedMapTx[T]) Get(key T) (interface{}, bool) {
+	if w, ok := tx.writes[key]; ok {
+		return w.value, !w.deleted
+	}
+	return tx.view().Get(key)
+}
+
+// Keys returns the keys as seen by the transaction in insertion order.
+func (tx *OrderedMapTx[T]) Keys() []T {
+	view := tx.view()
+	// moved holds, for every key that left its place in the view or was not
+	// in it, the op that last added it, or -1 if it ends up deleted.
+	moved := make(map[T]int)
+	for i, op := range tx.ops {
+		last, ok := moved[op.key]
+		present := last >= 0
+		if !ok {
+			_, present = view.Get(op.key)
+		}
+		if op.deleted && present {
+			moved[op.key] = -1
+		} else if !op.deleted && !present {
+			moved[op.key] = i
+		}
+	}
+
+	keys := make([]T, 0, view.Len()+len(moved))
+	for _, k := range view.Keys() {
+		if _, ok := moved[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	for i, op := range tx.ops {
+		if last, ok := moved[op.key]; ok && last == i {
+			keys = append(keys, op.key)
+		}
+	}
+	return keys
+}
+
+// Len returns the number of pairs as seen by the transaction.
+func (tx *OrderedMapTx[T]) Len() int {
+	view := tx.view()
+	n := view.Len()
+	for k, w := range tx.writes {
+		_, ok := view.Get(k)
+		if ok && w.deleted {
+			n--
+		} else if !ok && !w.deleted {
+			n++
+		}
+	}
+	return n
+}
+
+// Commit applies the writes of the transaction to the map at once.
+//
+// Returns ErrTxConflict under SnapshotIsolation if another transaction
+// committed one of the same keys since Begin, in which case nothing is
+// applied, and ErrTxDone if the transaction has already finished.
+func (tx *OrderedMapTx[T]) Commit() error {
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.done = true
+	if len(tx.ops) == 0 {
+		return nil
+	}
+	keys := make([]T, 0, len(tx.writes))
+	for k := range tx.writes {
+		keys = append(keys, k)
+	}
+
+	l := &tx.m.log
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if tx.m.isolation == SnapshotIsolation && l.conflicts(tx.version, keys) {
+		return ErrTxConflict
+	}
+	tx.m.committed.Store(tx.apply(tx.m.committed.Load()))
+	l.record(keys)
+	return nil
+}
+
+// Rollback discards the writes of the transaction. It does nothing if the
+// transaction has already finished.
+func (tx *OrderedMapTx[T]) Rollback() {
+	tx.done = true
+	tx.writes = nil
+	tx.ops = nil
+}
+
+func (tx *OrderedMapTx[T]) write(key T, value interface{}, deleted bool) {
+	if tx.done {
+		panic("collections: transaction already finished")
+	}
+	w := txWrite{value, deleted}
+	tx.writes[key] = w
+	tx.ops = append(tx.ops, txOp[T]{key, w})
+}
+
+// view returns the committed state the transaction reads through to.
+func (tx *OrderedMapTx[T]) view() *OrderedMap[T] {
+	if tx.m.isolation == SnapshotIsolation {
+		return tx.base
+	}
+	return tx.m.committed.Load()
+}
+
+// apply returns a copy of m with the writes of the transaction replayed in
+// order, so that keys keep the position they would have in a plain
+// OrderedMap.
+func (tx *OrderedMapTx[T]) apply(m *OrderedMap[T]) *OrderedMap[T] {
+	cp := copyOrderedMap(m)
+	for _, op := range tx.ops {
+		if op.deleted {
+			cp.Delete(op.key)
+		} else {
+			cp.Set(op.key, op.value)
+		}
+	}
+	return cp
+}
+
+func copyOrderedMap[T comparable](m *OrderedMap[T]) *OrderedMap[T] {
+	cp := NewOrderedMap[T]()
+	for _, kv := range m.ToKeyValueArray() {
+		cp.Set(kv.Key, kv.Value)
+	}
+	return cp
+}
+
+type TxSet[T comparable] struct {
+	isolation Isolation
+	log       txLog[T]
+	committed atomic.Pointer[Set[T]]
+}
+
+type SetTx[T comparable] struct {
+	s       *TxSet[T]
+	base    *Set[T]
+	version uint64
+	writes  map[T]bool
+	done    bool
+}
+
+// NewTxSet creates a Set with the given elements that is changed through
+// transactions. Every commit publishes a new copy of the set, so readers
+// never block and always see a committed state.
+//
+// Returns a pointer to the new TxSet.
+func NewTxSet[T comparable](isolation Isolation, elems ...T) *TxSet[T] {
+	s := &TxSet[T]{isolation: isolation, log: txLog[T]{versions: make(map[T]uint64)}}
+	set := NewSet(elems...)
+	s.committed.Store(&set)
+	return s
+}
+
+// Begin starts a transaction. Its writes are recorded on the side and only
+// become visible to others when it commits.
+//
+// Returns a pointer to the new SetTx.
+func (s *TxSet[T]) Begin() *SetTx[T] {
+	s.log.mu.Lock()
+	defer s.log.mu.Unlock()
+	return &SetTx[T]{s: s, base: s.committed.Load(), version: s.log.version, writes: make(map[T]bool)}
+}
+
+// Update runs fn in a new transaction and commits it if fn returns nil. The
+// transaction is rolled back if fn returns an error or panics.
+//
+// Returns the error of fn or of Commit.
+func (s *TxSet[T]) Update(fn func(tx *SetTx[T]) error) error {
+	tx := s.Begin()
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Snapshot returns a copy of the committed set.
+func (s *TxSet[T]) Snapshot() Set[T] {
+	return s.committed.Load().Copy()
+}
+
+// Contains checks if all elements are in the committed set.
+func (s *TxSet[T]) Contains(elems ...T) bool {
+	return s.committed.Load().Contains(elems...)
+}
+
+// Len returns the number of committed elements.
+func (s *TxSet[T]) Len() int {
+	return s.committed.Load().Len()
+}
+
+// Add records the addition of elements.
+//
+// It panics if the transaction has finished.
+func (tx *SetTx[T]) Add(elems ...T) {
+	tx.write(elems, true)
+}
+
+// Remove records the removal of elements.
+//
+// It panics if the transaction has finished.
+func (tx *SetTx[T]) Remove(elems ...T) {
+	tx.write(elems, false)
+}
+
+// Contains checks if all elements are in the set as seen by the
+// transaction, including its own writes.
+func (tx *SetTx[T]) Contains(elems ...T) bool {
+	view := tx.view()
+	for _, e := range elems {
+		added, ok := tx.writes[e]
+		if !ok {
+			added = view.Contains(e)
+		}
+		if !added {
+			return false
+		}
+	}
+	return true
+}
+
+// ToSlice returns the elements as seen by the transaction.
+func (tx *SetTx[T]) ToSlice() []T {
+	return tx.apply(tx.view()).ToSlice()
+}
+
+// Len returns the number of elements as seen by the transaction.
+func (tx *SetTx[T]) Len() int {
+	view := tx.view()
+	n := view.Len()
+	for e, added := range tx.writes {
+		if in := view.Contains(e); in && !added {
+			n--
+		} else if !in && added {
+			n++
+		}
+	}
+	return n
+}
+
+// Commit applies the writes of the transaction to the set at once.
+//
+// Returns ErrTxConflict under SnapshotIsolation if another transaction
+// committed one of the same elements since Begin, in which case nothing is
+// applied, and ErrTxDone if the transaction has already finished.
+func (tx *SetTx[T]) Commit() error {
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.done = true
+	if len(tx.writes) == 0 {
+		return nil
+	}
+	keys := make([]T, 0, len(tx.writes))
+	for k := range tx.writes {
+		keys = append(keys, k)
+	}
+
+	l := &tx.s.log
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if tx.s.isolation == SnapshotIsolation && l.conflicts(tx.version, keys) {
+		return ErrTxConflict
+	}
+	set := tx.apply(tx.s.committed.Load())
+	tx.s.committed.Store(&set)
+	l.record(keys)
+	return nil
+}
+
+// Rollback discards the writes of the transaction. It does nothing if the
+// transaction has already finished.
+func (tx *SetTx[T]) Rollback() {
+	tx.done = true
+	tx.writes = nil
+}
+
+func (tx *SetTx[T]) write(elems []T, added bool) {
+	if tx.done {
+		panic("collections: transaction already finished")
+	}
+	for _, e := range elems {
+		tx.writes[e] = added
+	}
+}
+
+// view returns the committed state the transaction reads through to.
+func (tx *SetTx[T]) view() *Set[T] {
+	if tx.s.isolation == SnapshotIsolation {
+		return tx.base
+	}
+	return tx.s.committed.Load()
+}
+
+// apply returns a copy of s with the writes of the transaction.
+func (tx *SetTx[T]) apply(s *Set[T]) Set[T] {
+	cp := s.Copy()
+	for e, added := range tx.writes {
+		if added {
+			cp.Add(e)
+		} else {
+			cp.Remove(e)
+		}
+	}
+	return cp
+}
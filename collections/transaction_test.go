@@ -0,0 +1,203 @@
+package collections
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestTxOrderedMap_CommitAndRollback(t *testing.T) {
+	m := NewTxOrderedMap[string](ReadCommitted)
+	tx := m.Begin()
+	tx.Set("a", 1)
+	tx.Set("b", 2)
+	tx.Delete("a")
+	tx.Set("a", 3)
+
+	// Read-your-writes inside the transaction, nothing outside.
+	if v, ok := tx.Get("a"); !ok || v != 3 {
+		t.Errorf("Expected 3, but got %v", v)
+	}
+	if !reflect.DeepEqual(tx.Keys(), []string{"b", "a"}) || tx.Len() != 2 {
+		t.Errorf("Expected [b a], but got %v", tx.Keys())
+	}
+	if m.Len() != 0 {
+		t.Errorf("Expected uncommitted writes to be invisible")
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	if !reflect.DeepEqual(m.Keys(), []string{"b", "a"}) {
+		t.Errorf("Expected [b a], but got %v", m.Keys())
+	}
+	if err := tx.Commit(); err != ErrTxDone {
+		t.Errorf("Expected ErrTxDone, but got %v", err)
+	}
+
+	failure := errors.New("step 2 failed")
+	err := m.Update(func(tx *OrderedMapTx[string]) error {
+		tx.Delete("b")
+		tx.Set("c", 4)
+		return failure
+	})
+	if err != failure {
+		t.Errorf("Expected %v, but got %v", failure, err)
+	}
+	if v, _ := m.Get("b"); v != 2 || m.Len() != 2 {
+		t.Errorf("Expected the failed update to be rolled back")
+	}
+
+	func() {
+		defer func() { recover() }()
+		m.Update(func(tx *OrderedMapTx[string]) error {
+			tx.Set("b", 5)
+			panic("boom")
+		})
+	}()
+	if v, _ := m.Get("b"); v != 2 {
+		t.Errorf("Expected a panicking update to be rolled back")
+	}
+}
+
+// TestOrderedMapTx_KeysAndLen checks the view of a transaction against a
+// plain OrderedMap that gets the same writes.
+func TestOrderedMapTx_KeysAndLen(t *testing.T) {
+	m := NewTxOrderedMap[int](SnapshotIsolation)
+	m.Update(func(tx *OrderedMapTx[int]) error {
+		for k := 0; k < 10; k++ {
+			tx.Set(k, k)
+		}
+		return nil
+	})
+	expected := m.Snapshot()
+
+	rng := rand.New(rand.NewSource(1))
+	tx := m.Begin()
+	for i := 0; i < 500; i++ {
+		k := rng.Intn(20)
+		if rng.Intn(3) == 0 {
+			tx.Delete(k)
+			expected.Delete(k)
+		} else {
+			tx.Set(k, i)
+			expected.Set(k, i)
+		}
+		if !reflect.DeepEqual(tx.Keys(), expected.Keys()) || tx.Len() != expected.Len() {
+			t.Fatalf("Step %v: expected %v, but got %v", i, expected.Keys(), tx.Keys())
+		}
+	}
+	if m.Len() != 10 {
+		t.Errorf("Expected the snapshot to be a copy, but the map has %v keys", m.Len())
+	}
+}
+
+func TestTxOrderedMap_Isolation(t *testing.T) {
+	m := NewTxOrderedMap[string](SnapshotIsolation)
+	m.Update(func(tx *OrderedMapTx[string]) error { tx.Set("x", 1); return nil })
+
+	t1, t2 := m.Begin(), m.Begin()
+	t1.Set("x", 2)
+	t1.Set("y", 1)
+	if err := t1.Commit(); err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+
+	// t2 still reads the snapshot it began with.
+	if v, _ := t2.Get("x"); v != 1 {
+		t.Errorf("Expected the snapshot value 1, but got %v", v)
+	}
+	if _, ok := t2.Get("y"); ok {
+		t.Errorf("Expected y to be invisible to t2")
+	}
+	t2.Set("x", 3)
+	if err := t2.Commit(); err != ErrTxConflict {
+		t.Errorf("Expected ErrTxConflict, but got %v", err)
+	}
+
+	// Disjoint writes do not conflict and keep the concurrent commit.
+	t3, t4 := m.Begin(), m.Begin()
+	t3.Set("x", 4)
+	t4.Set("z", 5)
+	if err := t3.Commit(); err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	if err := t4.Commit(); err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	if v, _ := m.Get("x"); v != 4 || !reflect.DeepEqual(m.Keys(), []string{"x", "y", "z"}) {
+		t.Errorf("Expected both commits, but got %v", m.Keys())
+	}
+
+	rc := NewTxOrderedMap[string](ReadCommitted)
+	r1, r2 := rc.Begin(), rc.Begin()
+	r1.Set("k", 1)
+	r1.Commit()
+	if v, _ := r2.Get("k"); v != 1 {
+		t.Errorf("Expected ReadCommitted to see the latest commit, but got %v", v)
+	}
+	r2.Set("k", 2)
+	if err := r2.Commit(); err != nil {
+		t.Errorf("Expected the last writer to win, but got %v", err)
+	}
+}
+
+func TestTxOrderedMap_ConcurrentReaders(t *testing.T) {
+	m := NewTxOrderedMap[int](SnapshotIsolation)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			m.Update(func(tx *OrderedMapTx[int]) error {
+				tx.Set(2*i, i)
+				tx.Set(2*i+1, i)
+				return nil
+			})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			// Both keys of an update are committed together.
+			if n := m.Snapshot().Len(); n%2 != 0 {
+				t.Errorf("Expected an even number of keys, but got %v", n)
+			}
+		}
+	}()
+	wg.Wait()
+	if m.Len() != 400 {
+		t.Errorf("Expected 400, but got %v", m.Len())
+	}
+}
+
+func TestTxSet(t *testing.T) {
+	s := NewTxSet(SnapshotIsolation, 1, 2)
+	tx := s.Begin()
+	tx.Add(3)
+	tx.Remove(1)
+	if !tx.Contains(2, 3) || tx.Contains(1) || tx.Len() != 2 || s.Contains(3) {
+		t.Errorf("Expected read-your-writes only inside the transaction")
+	}
+
+	other := s.Begin()
+	other.Remove(3)
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	if err := other.Commit(); err != ErrTxConflict {
+		t.Errorf("Expected ErrTxConflict, but got %v", err)
+	}
+	if !s.Snapshot().Equals(NewSet(2, 3)) {
+		t.Errorf("Expected [2 3], but got %v", s.Snapshot().ToSlice())
+	}
+
+	tx = s.Begin()
+	tx.Add(9)
+	tx.Rollback()
+	if err := tx.Commit(); err != ErrTxDone || s.Contains(9) {
+		t.Errorf("Expected the rolled back transaction to change nothing")
+	}
+}
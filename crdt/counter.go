@@ -0,0 +1,175 @@
+package crdt
+
+import (
+	"encoding/json"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+type GCounter struct {
+	replica string
+	counts  collections.Counter
+}
+
+// NewGCounter creates a grow-only counter for the given replica. Each replica
+// only increments its own entry, and merging takes the larger count of every
+// replica, so the value is the sum of all increments.
+//
+// replica must be unique among the replicas that are merged together.
+// Returns a pointer to the new GCounter.
+func NewGCounter(replica string) *GCounter {
+	return &GCounter{replica: replica, counts: collections.NewCounter()}
+}
+
+// Replica returns the replica ID the counter was created with.
+func (c *GCounter) Replica() string {
+	return c.replica
+}
+
+// Increment adds n to the entry of this replica.
+func (c *GCounter) Increment(n uint64) {
+	c.counts.Set(c.replica, c.counts.Get(c.replica)+n)
+}
+
+// Value returns the sum of the entries of all replicas.
+func (c *GCounter) Value() uint64 {
+	var sum uint64
+	for _, r := range c.counts.ToSlice() {
+		sum += c.counts.Get(r)
+	}
+	return sum
+}
+
+// Copy returns an independent copy of the counter with the same replica ID.
+func (c *GCounter) Copy() *GCounter {
+	cp := NewGCounter(c.replica)
+	cp.Merge(c)
+	return cp
+}
+
+// Merge keeps the larger entry of every replica.
+func (c *GCounter) Merge(other *GCounter) {
+	for _, r := range other.counts.ToSlice() {
+		c.counts.Set(r, max(c.counts.Get(r), other.counts.Get(r)))
+	}
+}
+
+// Delta returns the entries that are larger than in since. A nil since
+// returns a copy of the counter.
+func (c *GCounter) Delta(since *GCounter) *GCounter {
+	if since == nil {
+		return c.Copy()
+	}
+	delta := NewGCounter(c.replica)
+	for _, r := range c.counts.ToSlice() {
+		if n := c.counts.Get(r); n > since.counts.Get(r) {
+			delta.counts.Set(r, n)
+		}
+	}
+	return delta
+}
+
+// MarshalJSON encodes the counter as an object mapping replica IDs to their
+// entries.
+func (c *GCounter) MarshalJSON() ([]byte, error) {
+	counts := make(map[string]uint64, c.counts.Len())
+	for _, r := range c.counts.ToSlice() {
+		counts[r.(string)] = c.counts.Get(r)
+	}
+	return json.Marshal(counts)
+}
+
+// UnmarshalJSON replaces the entries of the counter with the ones encoded by
+// MarshalJSON and keeps the replica ID of the receiver.
+func (c *GCounter) UnmarshalJSON(data []byte) error {
+	var counts map[string]uint64
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return err
+	}
+	c.counts = collections.NewCounter()
+	for r, n := range counts {
+		c.counts.Set(r, n)
+	}
+	return nil
+}
+
+type PNCounter struct {
+	p *GCounter
+	n *GCounter
+}
+
+type pnCounterJSON struct {
+	P *GCounter `json:"p"`
+	N *GCounter `json:"n"`
+}
+
+// NewPNCounter creates a counter that can be incremented and decremented. It
+// is a pair of grow-only counters, one for increments and one for
+// decrements.
+//
+// replica must be unique among the replicas that are merged together.
+// Returns a pointer to the new PNCounter.
+func NewPNCounter(replica string) *PNCounter {
+	return &PNCounter{p: NewGCounter(replica), n: NewGCounter(replica)}
+}
+
+// Replica returns the replica ID the counter was created with.
+func (c *PNCounter) Replica() string {
+	return c.p.replica
+}
+
+// Increment adds n to the counter.
+func (c *PNCounter) Increment(n uint64) {
+	c.p.Increment(n)
+}
+
+// Decrement subtracts n from the counter.
+func (c *PNCounter) Decrement(n uint64) {
+	c.n.Increment(n)
+}
+
+// Value returns the increments minus the decrements of all replicas.
+func (c *PNCounter) Value() int64 {
+	return int64(c.p.Value() - c.n.Value())
+}
+
+// Copy returns an independent copy of the counter with the same replica ID.
+func (c *PNCounter) Copy() *PNCounter {
+	return &PNCounter{p: c.p.Copy(), n: c.n.Copy()}
+}
+
+// Merge merges the increments and the decrements of other.
+func (c *PNCounter) Merge(other *PNCounter) {
+	c.p.Merge(other.p)
+	c.n.Merge(other.n)
+}
+
+// Delta returns the entries that are larger than in since. A nil since
+// returns a copy of the counter.
+func (c *PNCounter) Delta(since *PNCounter) *PNCounter {
+	if since == nil {
+		return c.Copy()
+	}
+	return &PNCounter{p: c.p.Delta(since.p), n: c.n.Delta(since.n)}
+}
+
+// MarshalJSON encodes the counter as an object with the increments under "p"
+// and the decrements under "n".
+func (c *PNCounter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pnCounterJSON{c.p, c.n})
+}
+
+// UnmarshalJSON replaces the entries of the counter with the ones encoded by
+// MarshalJSON and keeps the replica ID of the receiver.
+func (c *PNCounter) UnmarshalJSON(data []byte) error {
+	replica := ""
+	if c.p != nil {
+		replica = c.p.replica
+	}
+	state := pnCounterJSON{NewGCounter(replica), NewGCounter(replica)}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	c.p, c.n = state.P, state.N
+	return nil
+}
@@ -0,0 +1,55 @@
+package crdt
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGCounter(t *testing.T) {
+	a, b := NewGCounter("a"), NewGCounter("b")
+	a.Increment(3)
+	b.Increment(2)
+	b.Increment(5)
+
+	a.Merge(b)
+	a.Merge(b)
+	if a.Value() != 10 {
+		t.Errorf("Expected 10, but got %v", a.Value())
+	}
+
+	delta := b.Delta(a)
+	if delta.Value() != 0 {
+		t.Errorf("Expected an empty delta, but got %v", delta.Value())
+	}
+	a.Increment(1)
+	if delta := a.Delta(b); delta.Value() != 4 {
+		t.Errorf("Expected only the entry of a in the delta, but got %v", delta.Value())
+	}
+}
+
+func TestPNCounter(t *testing.T) {
+	a, b := NewPNCounter("a"), NewPNCounter("b")
+	a.Increment(5)
+	b.Decrement(8)
+	a.Merge(b)
+	b.Merge(a)
+	if a.Value() != -3 || b.Value() != -3 {
+		t.Errorf("Expected -3, but got %v and %v", a.Value(), b.Value())
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	if string(data) != `{"p":{"a":5},"n":{"b":8}}` {
+		t.Errorf("Unexpected encoding %s", data)
+	}
+	restored := NewPNCounter("a")
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	restored.Increment(1)
+	if restored.Value() != -2 || restored.Replica() != "a" {
+		t.Errorf("Expected -2 on replica a, but got %v on %v", restored.Value(), restored.Replica())
+	}
+}
@@ -0,0 +1,165 @@
+package crdt
+
+import (
+	"encoding/json"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+type GSet[T comparable] struct {
+	elements collections.Set[T]
+}
+
+// NewGSet creates a grow-only set with the given elements. Elements can be
+// added but never removed, and merging two replicas takes their union.
+//
+// Returns a pointer to the new GSet.
+func NewGSet[T comparable](elems ...T) *GSet[T] {
+	return &GSet[T]{elements: collections.NewSet(elems...)}
+}
+
+// Add adds elements to the set.
+func (s *GSet[T]) Add(elems ...T) {
+	s.elements.Add(elems...)
+}
+
+// Contains checks if all elements are present in the set.
+func (s *GSet[T]) Contains(elems ...T) bool {
+	return s.elements.Contains(elems...)
+}
+
+// ToSlice returns the elements of the set in no particular order.
+func (s *GSet[T]) ToSlice() []T {
+	return s.elements.ToSlice()
+}
+
+// Len returns the number of elements in the set.
+func (s *GSet[T]) Len() int {
+	return s.elements.Len()
+}
+
+// Copy returns an independent copy of the set.
+func (s *GSet[T]) Copy() *GSet[T] {
+	return &GSet[T]{elements: s.elements.Copy()}
+}
+
+// Merge adds every element of other to the set.
+func (s *GSet[T]) Merge(other *GSet[T]) {
+	s.elements.Add(other.elements.ToSlice()...)
+}
+
+// Delta returns the part of the set that since does not have yet, so that
+// merging it into since has the same effect as merging the whole set. A nil
+// since returns a copy of the set.
+func (s *GSet[T]) Delta(since *GSet[T]) *GSet[T] {
+	if since == nil {
+		return s.Copy()
+	}
+	return &GSet[T]{elements: s.elements.Difference(since.elements)}
+}
+
+// MarshalJSON encodes the set as a JSON array.
+func (s *GSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.elements.ToSlice())
+}
+
+// UnmarshalJSON replaces the set with the elements of a JSON array.
+func (s *GSet[T]) UnmarshalJSON(data []byte) error {
+	var elems []T
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	s.elements = collections.NewSet(elems...)
+	return nil
+}
+
+type TwoPSet[T comparable] struct {
+	added   collections.Set[T]
+	removed collections.Set[T]
+}
+
+type twoPSetJSON[T comparable] struct {
+	Added   []T `json:"added"`
+	Removed []T `json:"removed"`
+}
+
+// NewTwoPSet creates a two-phase set: a pair of grow-only sets holding the
+// added elements and the removed ones. Removal wins, so an element that has
+// been removed can never be added again.
+//
+// Returns a pointer to the new TwoPSet.
+func NewTwoPSet[T comparable](elems ...T) *TwoPSet[T] {
+	return &TwoPSet[T]{added: collections.NewSet(elems...), removed: collections.NewSet[T]()}
+}
+
+// Add adds elements to the set. Elements that were removed before stay
+// removed.
+func (s *TwoPSet[T]) Add(elems ...T) {
+	s.added.Add(elems...)
+}
+
+// Remove removes elements from the set for good. Elements that were never
+// added are ignored.
+func (s *TwoPSet[T]) Remove(elems ...T) {
+	for _, e := range elems {
+		if s.added.Contains(e) {
+			s.removed.Add(e)
+		}
+	}
+}
+
+// Contains checks if all elements are present in the set.
+func (s *TwoPSet[T]) Contains(elems ...T) bool {
+	for _, e := range elems {
+		if !s.added.Contains(e) || s.removed.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// ToSlice returns the elements of the set in no particular order.
+func (s *TwoPSet[T]) ToSlice() []T {
+	return s.added.Difference(s.removed).ToSlice()
+}
+
+// Len returns the number of elements in the set.
+func (s *TwoPSet[T]) Len() int {
+	return s.added.Difference(s.removed).Len()
+}
+
+// Copy returns an independent copy of the set.
+func (s *TwoPSet[T]) Copy() *TwoPSet[T] {
+	return &TwoPSet[T]{added: s.added.Copy(), removed: s.removed.Copy()}
+}
+
+// Merge takes the union of both the added and the removed elements of other.
+func (s *TwoPSet[T]) Merge(other *TwoPSet[T]) {
+	s.added.Add(other.added.ToSlice()...)
+	s.removed.Add(other.removed.ToSlice()...)
+}
+
+// Delta returns the additions and removals that since does not have yet. A
+// nil since returns a copy of the set.
+func (s *TwoPSet[T]) Delta(since *TwoPSet[T]) *TwoPSet[T] {
+	if since == nil {
+		return s.Copy()
+	}
+	return &TwoPSet[T]{added: s.added.Difference(since.added), removed: s.removed.Difference(since.removed)}
+}
+
+// MarshalJSON encodes the set as an object with "added" and "removed" arrays.
+func (s *TwoPSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(twoPSetJSON[T]{Added: s.added.ToSlice(), Removed: s.removed.ToSlice()})
+}
+
+// UnmarshalJSON replaces the set with the state encoded by MarshalJSON.
+func (s *TwoPSet[T]) UnmarshalJSON(data []byte) error {
+	var state twoPSetJSON[T]
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	s.added = collections.NewSet(state.Added...)
+	s.removed = collections.NewSet(state.Removed...)
+	return nil
+}
@@ -0,0 +1,49 @@
+package crdt
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGSet(t *testing.T) {
+	a := NewGSet("x")
+	b := NewGSet("y")
+	a.Add("z")
+	a.Merge(b)
+	if !a.Contains("x", "y", "z") || a.Len() != 3 {
+		t.Errorf("Expected [x y z], but got %v", a.ToSlice())
+	}
+
+	delta := a.Delta(b)
+	if delta.Len() != 2 || delta.Contains("y") {
+		t.Errorf("Expected the delta to hold x and z, but got %v", delta.ToSlice())
+	}
+	if full := a.Delta(nil); full.Len() != 3 {
+		t.Errorf("Expected a nil since to return the whole set, but got %v", full.ToSlice())
+	}
+}
+
+func TestTwoPSet(t *testing.T) {
+	a := NewTwoPSet("x", "y")
+	b := a.Copy()
+
+	a.Remove("x", "missing")
+	b.Add("z")
+	a.Merge(b)
+	if a.Contains("x") || !a.Contains("y", "z") || a.Len() != 2 {
+		t.Errorf("Expected [y z], but got %v", a.ToSlice())
+	}
+
+	// A removed element never comes back.
+	a.Add("x")
+	b.Merge(a)
+	if b.Contains("x") {
+		t.Errorf("Expected x to stay removed")
+	}
+
+	data, _ := json.Marshal(a)
+	decoded := NewTwoPSet[string]()
+	if err := json.Unmarshal(data, decoded); err != nil || decoded.Len() != 2 || decoded.Contains("x") {
+		t.Errorf("Expected the state to survive a JSON round trip, but got %v, %v", decoded.ToSlice(), err)
+	}
+}
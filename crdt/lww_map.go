@@ -0,0 +1,190 @@
+package crdt
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type lwwRegister[V any] struct {
+	value     V
+	timestamp int64
+	replica   string
+	deleted   bool
+}
+
+// newer reports whether r was written after other. Writes with the same
+// timestamp are ordered by replica ID, so every replica picks the same one.
+func (r lwwRegister[V]) newer(other lwwRegister[V]) bool {
+	if r.timestamp != other.timestamp {
+		return r.timestamp > other.timestamp
+	}
+	return r.replica > other.replica
+}
+
+type LWWMap[K comparable, V any] struct {
+	replica   string
+	clock     int64
+	now       func() int64
+	registers map[K]lwwRegister[V]
+}
+
+type lwwMapEntryJSON[K comparable, V any] struct {
+	Key       K      `json:"key"`
+	Value     V      `json:"value"`
+	Timestamp int64  `json:"timestamp"`
+	Replica   string `json:"replica"`
+	Deleted   bool   `json:"deleted,omitempty"`
+}
+
+// NewLWWMap creates an empty map of last-writer-wins registers for the given
+// replica. Every Set and Delete is stamped with the wall clock, kept
+// monotonic and ahead of every stamp seen in a merge, and merging keeps the
+// newest write of every key. Deletes are kept as tombstones so that they win
+// over older writes.
+//
+// replica must be unique among the replicas that are merged together.
+// Returns a pointer to the new LWWMap.
+func NewLWWMap[K comparable, V any](replica string) *LWWMap[K, V] {
+	return &LWWMap[K, V]{
+		replica:   replica,
+		now:       func() int64 { return time.Now().UnixNano() },
+		registers: make(map[K]lwwRegister[V]),
+	}
+}
+
+// Replica returns the replica ID the map was created with.
+func (m *LWWMap[K, V]) Replica() string {
+	return m.replica
+}
+
+// Set writes value for key.
+func (m *LWWMap[K, V]) Set(key K, value V) {
+	m.registers[key] = lwwRegister[V]{value: value, timestamp: m.tick(), replica: m.replica}
+}
+
+// Delete removes key.
+//
+// Returns false if key was not present.
+func (m *LWWMap[K, V]) Delete(key K) bool {
+	if _, ok := m.Get(key); !ok {
+		return false
+	}
+	m.registers[key] = lwwRegister[V]{timestamp: m.tick(), replica: m.replica, deleted: true}
+	return true
+}
+
+// Get returns the value for key and true if it is present.
+func (m *LWWMap[K, V]) Get(key K) (V, bool) {
+	r, ok := m.registers[key]
+	if !ok || r.deleted {
+		var zero V
+		return zero, false
+	}
+	return r.value, true
+}
+
+// Contains checks if all keys are present.
+func (m *LWWMap[K, V]) Contains(keys ...K) bool {
+	for _, k := range keys {
+		if _, ok := m.Get(k); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Keys returns the present keys in no particular order.
+func (m *LWWMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.registers))
+	for k, r := range m.registers {
+		if !r.deleted {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Len returns the number of present keys.
+func (m *LWWMap[K, V]) Len() int {
+	n := 0
+	for _, r := range m.registers {
+		if !r.deleted {
+			n++
+		}
+	}
+	return n
+}
+
+// Copy returns an independent copy of the map with the same replica ID.
+// Values are copied shallowly.
+func (m *LWWMap[K, V]) Copy() *LWWMap[K, V] {
+	cp := NewLWWMap[K, V](m.replica)
+	cp.now = m.now
+	cp.Merge(m)
+	return cp
+}
+
+// Merge keeps the newest write of every key from both maps.
+func (m *LWWMap[K, V]) Merge(other *LWWMap[K, V]) {
+	for k, r := range other.registers {
+		m.merge(k, r)
+	}
+}
+
+// Delta returns the writes that are newer than in since. A nil since returns
+// a copy of the map.
+func (m *LWWMap[K, V]) Delta(since *LWWMap[K, V]) *LWWMap[K, V] {
+	if since == nil {
+		return m.Copy()
+	}
+	delta := NewLWWMap[K, V](m.replica)
+	delta.now = m.now
+	for k, r := range m.registers {
+		if known, ok := since.registers[k]; !ok || r.newer(known) {
+			delta.merge(k, r)
+		}
+	}
+	return delta
+}
+
+// MarshalJSON encodes every write, including deletes, as an array of
+// objects.
+func (m *LWWMap[K, V]) MarshalJSON() ([]byte, error) {
+	entries := make([]lwwMapEntryJSON[K, V], 0, len(m.registers))
+	for k, r := range m.registers {
+		entries = append(entries, lwwMapEntryJSON[K, V]{k, r.value, r.timestamp, r.replica, r.deleted})
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON replaces the writes of the map with the ones encoded by
+// MarshalJSON and keeps the replica ID of the receiver.
+func (m *LWWMap[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []lwwMapEntryJSON[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	if m.now == nil {
+		m.now = func() int64 { return time.Now().UnixNano() }
+	}
+	m.registers = make(map[K]lwwRegister[V], len(entries))
+	for _, e := range entries {
+		m.merge(e.Key, lwwRegister[V]{e.Value, e.Timestamp, e.Replica, e.Deleted})
+	}
+	return nil
+}
+
+// merge stores r for key if it is newer than the current write and moves the
+// clock past its timestamp.
+func (m *LWWMap[K, V]) merge(key K, r lwwRegister[V]) {
+	m.clock = max(m.clock, r.timestamp)
+	if current, ok := m.registers[key]; !ok || r.newer(current) {
+		m.registers[key] = r
+	}
+}
+
+// tick returns a timestamp later than every one written or merged so far.
+func (m *LWWMap[K, V]) tick() int64 {
+	m.clock = max(m.now(), m.clock+1)
+	return m.clock
+}
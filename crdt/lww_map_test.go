@@ -0,0 +1,62 @@
+package crdt
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestLWWMap(t *testing.T) {
+	now := int64(100)
+	clock := func() int64 { return now }
+	a := NewLWWMap[string, string]("a")
+	b := NewLWWMap[string, string]("b")
+	a.now, b.now = clock, clock
+
+	a.Set("color", "red")
+	now = 200
+	b.Set("color", "blue")
+	a.Merge(b)
+	if v, _ := a.Get("color"); v != "blue" {
+		t.Errorf("Expected the newest write blue, but got %v", v)
+	}
+
+	// Concurrent writes with the same timestamp go to the larger replica ID.
+	a.Set("size", "small")
+	b.Set("size", "large")
+	a.Merge(b)
+	b.Merge(a)
+	va, _ := a.Get("size")
+	vb, _ := b.Get("size")
+	if va != "large" || vb != "large" {
+		t.Errorf("Expected both replicas to pick large, but got %v and %v", va, vb)
+	}
+
+	// A delete wins over older writes, even with a clock that went back.
+	now = 50
+	if !a.Delete("color") || a.Delete("color") {
+		t.Errorf("Expected exactly one successful delete")
+	}
+	b.Merge(a)
+	if b.Contains("color") || b.Len() != 1 {
+		t.Errorf("Expected color to be deleted, but got %v", b.Keys())
+	}
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	restored := NewLWWMap[string, string]("b")
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	keys := restored.Keys()
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, []string{"size"}) || restored.Contains("color") {
+		t.Errorf("Expected [size], but got %v", keys)
+	}
+	if !reflect.DeepEqual(restored.registers, b.registers) {
+		t.Errorf("Expected the tombstone to survive the round trip")
+	}
+}
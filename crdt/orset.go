@@ -0,0 +1,201 @@
+package crdt
+
+import (
+	"encoding/json"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+// Tag identifies a single Add on an ORSet: the replica that made it and that
+// replica's sequence number.
+type Tag struct {
+	Replica string `json:"replica"`
+	Seq     uint64 `json:"seq"`
+}
+
+type ORSet[T comparable] struct {
+	replica    string
+	seq        uint64
+	entries    map[T]collections.Set[Tag]
+	tombstones collections.Set[Tag]
+}
+
+type orSetEntryJSON[T comparable] struct {
+	Element T     `json:"element"`
+	Tags    []Tag `json:"tags"`
+}
+
+type orSetJSON[T comparable] struct {
+	Entries    []orSetEntryJSON[T] `json:"entries"`
+	Tombstones []Tag               `json:"tombstones"`
+}
+
+// NewORSet creates an empty observed-remove set for the given replica.
+// Every Add is tagged uniquely and Remove only removes the tags it has
+// observed, so an Add concurrent with a Remove survives the merge and an
+// element can be added again after it was removed. Removed tags are kept as
+// tombstones, so the state grows with the number of removals.
+//
+// replica must be unique among the replicas that are merged together.
+// Returns a pointer to the new ORSet.
+func NewORSet[T comparable](replica string) *ORSet[T] {
+	return &ORSet[T]{
+		replica:    replica,
+		entries:    make(map[T]collections.Set[Tag]),
+		tombstones: collections.NewSet[Tag](),
+	}
+}
+
+// Replica returns the replica ID the set was created with.
+func (s *ORSet[T]) Replica() string {
+	return s.replica
+}
+
+// Add adds elements to the set, each with a new tag.
+func (s *ORSet[T]) Add(elems ...T) {
+	for _, e := range elems {
+		s.seq++
+		s.tag(e, Tag{s.replica, s.seq})
+	}
+}
+
+// Remove removes elements from the set by tombstoning every tag observed for
+// them.
+func (s *ORSet[T]) Remove(elems ...T) {
+	for _, e := range elems {
+		if tags, ok := s.entries[e]; ok {
+			s.tombstones.Add(tags.ToSlice()...)
+			delete(s.entries, e)
+		}
+	}
+}
+
+// Contains checks if all elements are present in the set.
+func (s *ORSet[T]) Contains(elems ...T) bool {
+	for _, e := range elems {
+		if _, ok := s.entries[e]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ToSlice returns the elements of the set in no particular order.
+func (s *ORSet[T]) ToSlice() []T {
+	elems := make([]T, 0, len(s.entries))
+	for e := range s.entries {
+		elems = append(elems, e)
+	}
+	return elems
+}
+
+// Len returns the number of elements in the set.
+func (s *ORSet[T]) Len() int {
+	return len(s.entries)
+}
+
+// Copy returns an independent copy of the set with the same replica ID.
+func (s *ORSet[T]) Copy() *ORSet[T] {
+	cp := NewORSet[T](s.replica)
+	cp.seq = s.seq
+	for e, tags := range s.entries {
+		cp.entries[e] = tags.Copy()
+	}
+	cp.tombstones = s.tombstones.Copy()
+	return cp
+}
+
+// Merge adds the tags and tombstones of other. An element stays in the set as
+// long as one of its tags is not tombstoned on either side.
+func (s *ORSet[T]) Merge(other *ORSet[T]) {
+	s.tombstones.Add(other.tombstones.ToSlice()...)
+	for e, tags := range other.entries {
+		for _, t := range tags.ToSlice() {
+			s.tag(e, t)
+		}
+	}
+	for e, tags := range s.entries {
+		for _, t := range tags.ToSlice() {
+			if s.tombstones.Contains(t) {
+				tags.Remove(t)
+			}
+		}
+		if tags.IsEmpty() {
+			delete(s.entries, e)
+		}
+	}
+	for _, t := range other.tombstones.ToSlice() {
+		s.observe(t)
+	}
+}
+
+// Delta returns the tags and tombstones that since does not have yet. A nil
+// since returns a copy of the set.
+func (s *ORSet[T]) Delta(since *ORSet[T]) *ORSet[T] {
+	if since == nil {
+		return s.Copy()
+	}
+	delta := NewORSet[T](s.replica)
+	delta.seq = s.seq
+	for e, tags := range s.entries {
+		for _, t := range tags.ToSlice() {
+			if known, ok := since.entries[e]; (!ok || !known.Contains(t)) && !since.tombstones.Contains(t) {
+				delta.tag(e, t)
+			}
+		}
+	}
+	delta.tombstones = s.tombstones.Difference(since.tombstones)
+	return delta
+}
+
+// MarshalJSON encodes the tags of every element and the tombstones. The
+// replica ID is not part of the encoding.
+func (s *ORSet[T]) MarshalJSON() ([]byte, error) {
+	state := orSetJSON[T]{Entries: make([]orSetEntryJSON[T], 0, len(s.entries)), Tombstones: s.tombstones.ToSlice()}
+	for e, tags := range s.entries {
+		state.Entries = append(state.Entries, orSetEntryJSON[T]{e, tags.ToSlice()})
+	}
+	return json.Marshal(state)
+}
+
+// UnmarshalJSON replaces the state of the set with the one encoded by
+// MarshalJSON and keeps the replica ID of the receiver. Its sequence number
+// moves past every tag of its own replica, so new tags stay unique.
+func (s *ORSet[T]) UnmarshalJSON(data []byte) error {
+	var state orSetJSON[T]
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	s.entries = make(map[T]collections.Set[Tag], len(state.Entries))
+	s.tombstones = collections.NewSet(state.Tombstones...)
+	for _, entry := range state.Entries {
+		for _, t := range entry.Tags {
+			s.tag(entry.Element, t)
+		}
+	}
+	for _, t := range state.Tombstones {
+		s.observe(t)
+	}
+	return nil
+}
+
+// tag adds t to the tags of e unless it is tombstoned.
+func (s *ORSet[T]) tag(e T, t Tag) {
+	s.observe(t)
+	if s.tombstones.Contains(t) {
+		return
+	}
+	tags, ok := s.entries[e]
+	if !ok {
+		tags = collections.NewSet[Tag]()
+		s.entries[e] = tags
+	}
+	tags.Add(t)
+}
+
+// observe moves the sequence number past t if it belongs to this replica.
+func (s *ORSet[T]) observe(t Tag) {
+	if t.Replica == s.replica && t.Seq > s.seq {
+		s.seq = t.Seq
+	}
+}
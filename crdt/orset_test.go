@@ -0,0 +1,59 @@
+package crdt
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestORSet_AddWins(t *testing.T) {
+	a := NewORSet[string]("a")
+	a.Add("flag")
+	b := NewORSet[string]("b")
+	b.Merge(a)
+
+	// a removes the flag while b adds it again concurrently.
+	a.Remove("flag")
+	b.Add("flag")
+	a.Merge(b)
+	b.Merge(a)
+	if !a.Contains("flag") || !b.Contains("flag") {
+		t.Errorf("Expected the concurrent add to survive the remove")
+	}
+
+	// A remove that has observed every add wins.
+	b.Remove("flag")
+	a.Merge(b)
+	if a.Contains("flag") || a.Len() != 0 {
+		t.Errorf("Expected the flag to be removed, but got %v", a.ToSlice())
+	}
+
+	// Unlike a TwoPSet, an element can be added back.
+	a.Add("flag")
+	if !a.Contains("flag") {
+		t.Errorf("Expected the flag to be added back")
+	}
+}
+
+func TestORSet_JSON(t *testing.T) {
+	a := NewORSet[string]("a")
+	a.Add("x", "y", "z")
+	a.Remove("y")
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	restored := NewORSet[string]("a")
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	if restored.Replica() != "a" || restored.Len() != 2 || !restored.Contains("x", "z") {
+		t.Errorf("Expected [x z] on replica a, but got %v on %v", restored.ToSlice(), restored.Replica())
+	}
+
+	// New tags must not reuse the tombstoned tag of y.
+	restored.Add("y")
+	if !restored.Contains("y") {
+		t.Errorf("Expected y to be added after the restore")
+	}
+}
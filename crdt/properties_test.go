@@ -0,0 +1,180 @@
+package crdt
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type mergeable[S any] interface {
+	Copy() S
+	Merge(other S)
+	Delta(since S) S
+}
+
+// checkMerge builds three replicas from random operations and random partial
+// merges, and checks that merging them is commutative, associative and
+// idempotent, that a delta has the same effect as the full state, and that
+// the state survives a JSON round trip. state must describe a replica in a
+// canonical form.
+func checkMerge[S mergeable[S]](t *testing.T, fresh func(replica string) S, op func(rng *rand.Rand, s S), state func(S) string) {
+	t.Helper()
+	merged := func(x, y S) S {
+		cp := x.Copy()
+		cp.Merge(y)
+		return cp
+	}
+	for seed := int64(0); seed < 50; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		replicas := []S{fresh("a"), fresh("b"), fresh("c")}
+		for i := 0; i < 40; i++ {
+			r := replicas[rng.Intn(len(replicas))]
+			if rng.Intn(5) == 0 {
+				r.Merge(replicas[rng.Intn(len(replicas))])
+			} else {
+				op(rng, r)
+			}
+		}
+		a, b, c := replicas[0], replicas[1], replicas[2]
+
+		if x, y := state(merged(a, b)), state(merged(b, a)); x != y {
+			t.Fatalf("Seed %v: merge is not commutative: %v != %v", seed, x, y)
+		}
+		if x, y := state(merged(merged(a, b), c)), state(merged(a, merged(b, c))); x != y {
+			t.Fatalf("Seed %v: merge is not associative: %v != %v", seed, x, y)
+		}
+		if x, y := state(merged(a, a)), state(a); x != y {
+			t.Fatalf("Seed %v: merge is not idempotent: %v != %v", seed, x, y)
+		}
+		if x, y := state(merged(b, a.Delta(b))), state(merged(b, a)); x != y {
+			t.Fatalf("Seed %v: delta differs from full merge: %v != %v", seed, x, y)
+		}
+
+		data, err := json.Marshal(a)
+		if err != nil {
+			t.Fatalf("Expected nil, but got %v", err)
+		}
+		decoded := fresh("a")
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatalf("Expected nil, but got %v", err)
+		}
+		if x, y := state(decoded), state(a); x != y {
+			t.Fatalf("Seed %v: JSON round trip changed the state: %v != %v", seed, x, y)
+		}
+	}
+}
+
+func canonical[T any](elems []T) string {
+	parts := make([]string, len(elems))
+	for i, e := range elems {
+		parts[i] = fmt.Sprint(e)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+func TestGSet_MergeProperties(t *testing.T) {
+	checkMerge(t,
+		func(string) *GSet[int] { return NewGSet[int]() },
+		func(rng *rand.Rand, s *GSet[int]) { s.Add(rng.Intn(20)) },
+		func(s *GSet[int]) string { return canonical(s.ToSlice()) },
+	)
+}
+
+func TestTwoPSet_MergeProperties(t *testing.T) {
+	checkMerge(t,
+		func(string) *TwoPSet[int] { return NewTwoPSet[int]() },
+		func(rng *rand.Rand, s *TwoPSet[int]) {
+			if rng.Intn(3) == 0 {
+				s.Remove(rng.Intn(10))
+			} else {
+				s.Add(rng.Intn(10))
+			}
+		},
+		func(s *TwoPSet[int]) string {
+			return canonical(s.added.ToSlice()) + "|" + canonical(s.removed.ToSlice())
+		},
+	)
+}
+
+func TestORSet_MergeProperties(t *testing.T) {
+	checkMerge(t,
+		NewORSet[string],
+		func(rng *rand.Rand, s *ORSet[string]) {
+			e := string(rune('a' + rng.Intn(8)))
+			if rng.Intn(3) == 0 {
+				s.Remove(e)
+			} else {
+				s.Add(e)
+			}
+		},
+		func(s *ORSet[string]) string {
+			var tags []string
+			for e, ts := range s.entries {
+				for _, t := range ts.ToSlice() {
+					tags = append(tags, fmt.Sprint(e, t))
+				}
+			}
+			return canonical(tags) + "|" + canonical(s.tombstones.ToSlice())
+		},
+	)
+}
+
+func TestGCounter_MergeProperties(t *testing.T) {
+	checkMerge(t,
+		NewGCounter,
+		func(rng *rand.Rand, c *GCounter) { c.Increment(uint64(rng.Intn(5))) },
+		gCounterState,
+	)
+}
+
+func TestPNCounter_MergeProperties(t *testing.T) {
+	checkMerge(t,
+		NewPNCounter,
+		func(rng *rand.Rand, c *PNCounter) {
+			if rng.Intn(2) == 0 {
+				c.Decrement(uint64(rng.Intn(5)))
+			} else {
+				c.Increment(uint64(rng.Intn(5)))
+			}
+		},
+		func(c *PNCounter) string { return gCounterState(c.p) + "|" + gCounterState(c.n) },
+	)
+}
+
+func TestLWWMap_MergeProperties(t *testing.T) {
+	checkMerge(t,
+		func(replica string) *LWWMap[string, int] {
+			m := NewLWWMap[string, int](replica)
+			// A stopped clock makes concurrent writes share timestamps.
+			m.now = func() int64 { return 0 }
+			return m
+		},
+		func(rng *rand.Rand, m *LWWMap[string, int]) {
+			k := string(rune('a' + rng.Intn(6)))
+			if rng.Intn(4) == 0 {
+				m.Delete(k)
+			} else {
+				m.Set(k, rng.Intn(100))
+			}
+		},
+		func(m *LWWMap[string, int]) string {
+			var writes []string
+			for k, r := range m.registers {
+				writes = append(writes, fmt.Sprint(k, r))
+			}
+			return canonical(writes)
+		},
+	)
+}
+
+func gCounterState(c *GCounter) string {
+	var entries []string
+	for _, r := range c.counts.ToSlice() {
+		entries = append(entries, fmt.Sprint(r, "=", c.counts.Get(r)))
+	}
+	return canonical(entries)
+}
@@ -0,0 +1,60 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WriteDOT writes the graph to w in the Graphviz DOT language.
+//
+// Nodes are named with fmt.Sprint and every node and edge attribute is
+// written as a DOT attribute, so setting "label", "color" or "shape" changes
+// how the graph is drawn. Edge weights are not written unless they are also
+// stored as an attribute.
+func (g *Graph[N]) WriteDOT(w io.Writer, name string) error {
+	bw := bufio.NewWriter(w)
+	kind, arrow := "graph", "--"
+	if g.directed {
+		kind, arrow = "digraph", "->"
+	}
+	fmt.Fprintf(bw, "%s %s {\n", kind, strconv.Quote(name))
+	for _, n := range g.order.ToSlice() {
+		fmt.Fprintf(bw, "\t%s%s;\n", dotID(n), dotAttributes(g.nodes[n].attributes))
+	}
+	for _, e := range g.Edges() {
+		fmt.Fprintf(bw, "\t%s %s %s%s;\n", dotID(e.From), arrow, dotID(e.To), dotAttributes(e.Attributes))
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// DOT returns the graph in the Graphviz DOT language, as written by WriteDOT.
+func (g *Graph[N]) DOT(name string) string {
+	var sb strings.Builder
+	g.WriteDOT(&sb, name)
+	return sb.String()
+}
+
+func dotID(v any) string {
+	return strconv.Quote(fmt.Sprint(v))
+}
+
+func dotAttributes(attrs Attributes) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = strconv.Quote(k) + "=" + strconv.Quote(attrs[k])
+	}
+	return " [" + strings.Join(parts, ", ") + "]"
+}
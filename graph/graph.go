@@ -0,0 +1,295 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+var (
+	// ErrNodeNotFound is returned when an operation names a node that is not
+	// in the graph.
+	ErrNodeNotFound = errors.New("graph: node not found")
+	// ErrNoPath is returned when the target cannot be reached from the source.
+	ErrNoPath = errors.New("graph: no path between nodes")
+	// ErrNegativeWeight is returned by shortest path searches that meet an
+	// edge with a negative weight.
+	ErrNegativeWeight = errors.New("graph: negative edge weight")
+	// ErrUndirected is returned by operations that need a directed graph.
+	ErrUndirected = errors.New("graph: graph is undirected")
+	// ErrDirected is returned by operations that need an undirected graph.
+	ErrDirected = errors.New("graph: graph is directed")
+)
+
+// Attributes holds free-form metadata of a node or an edge. The keys and
+// values are written as-is when the graph is exported to DOT.
+type Attributes map[string]string
+
+// Edge connects two nodes.
+//
+// In an undirected graph the same Edge is returned for both directions, and
+// From and To keep the order the edge was added in.
+type Edge[N comparable] struct {
+	From       N
+	To         N
+	Weight     float64
+	Attributes Attributes
+}
+
+// Other returns the endpoint of e that is not n.
+func (e *Edge[N]) Other(n N) N {
+	if e.From == n {
+		return e.To
+	}
+	return e.From
+}
+
+type node[N comparable] struct {
+	attributes Attributes
+	out        map[N]*Edge[N]
+	outOrder   collections.OrderedSet[N]
+	in         map[N]*Edge[N]
+	inOrder    collections.OrderedSet[N]
+}
+
+type Graph[N comparable] struct {
+	directed bool
+	nodes    map[N]*node[N]
+	order    collections.OrderedSet[N]
+	edges    int
+}
+
+// NewDirected creates an empty directed graph.
+//
+// Nodes and edges are visited in the order they were added, so every
+// traversal and algorithm on the graph is deterministic.
+// Returns a pointer to the new Graph.
+func NewDirected[N comparable]() *Graph[N] {
+	return newGraph[N](true)
+}
+
+// NewUndirected creates an empty undirected graph.
+//
+// Returns a pointer to the new Graph.
+func NewUndirected[N comparable]() *Graph[N] {
+	return newGraph[N](false)
+}
+
+func newGraph[N comparable](directed bool) *Graph[N] {
+	return &Graph[N]{
+		directed: directed,
+		nodes:    make(map[N]*node[N]),
+		order:    collections.NewOrderedSet[N](),
+	}
+}
+
+// IsDirected checks if the graph is directed.
+func (g *Graph[N]) IsDirected() bool {
+	return g.directed
+}
+
+// AddNode adds n to the graph.
+//
+// Returns false if n was already present.
+func (g *Graph[N]) AddNode(n N) bool {
+	if _, ok := g.nodes[n]; ok {
+		return false
+	}
+	nd := &node[N]{
+		attributes: make(Attributes),
+		out:        make(map[N]*Edge[N]),
+		outOrder:   collections.NewOrderedSet[N](),
+	}
+	if g.directed {
+		nd.in = make(map[N]*Edge[N])
+		nd.inOrder = collections.NewOrderedSet[N]()
+	}
+	g.nodes[n] = nd
+	g.order.Add(n)
+	return true
+}
+
+// RemoveNode removes n and every edge that touches it.
+//
+// Returns false if n was not present.
+func (g *Graph[N]) RemoveNode(n N) bool {
+	nd, ok := g.nodes[n]
+	if !ok {
+		return false
+	}
+	for _, to := range nd.outOrder.ToSlice() {
+		g.RemoveEdge(n, to)
+	}
+	if g.directed {
+		for _, from := range nd.inOrder.ToSlice() {
+			g.RemoveEdge(from, n)
+		}
+	}
+	delete(g.nodes, n)
+	g.order.Remove(n)
+	return true
+}
+
+// HasNode checks if all the given nodes are in the graph.
+func (g *Graph[N]) HasNode(nodes ...N) bool {
+	return g.order.Contains(nodes...)
+}
+
+// Attributes returns the metadata of n, or nil if n is not in the graph.
+// The returned map is live and may be modified.
+func (g *Graph[N]) Attributes(n N) Attributes {
+	if nd, ok := g.nodes[n]; ok {
+		return nd.attributes
+	}
+	return nil
+}
+
+// Nodes returns the nodes in the order they were added.
+func (g *Graph[N]) Nodes() []N {
+	return g.order.ToSlice()
+}
+
+// NodeCount returns the number of nodes.
+func (g *Graph[N]) NodeCount() int {
+	return g.order.Len()
+}
+
+// AddEdge connects from to to with the given weight, adding missing nodes.
+//
+// A graph holds at most one edge between two nodes in each direction; if the
+// edge already exists only its weight is updated.
+// Returns the edge, whose Attributes may be modified.
+func (g *Graph[N]) AddEdge(from, to N, weight float64) *Edge[N] {
+	if e, ok := g.Edge(from, to); ok {
+		e.Weight = weight
+		return e
+	}
+	g.AddNode(from)
+	g.AddNode(to)
+
+	e := &Edge[N]{From: from, To: to, Weight: weight, Attributes: make(Attributes)}
+	src, dst := g.nodes[from], g.nodes[to]
+	src.out[to] = e
+	src.outOrder.Add(to)
+	if g.directed {
+		dst.in[from] = e
+		dst.inOrder.Add(from)
+	} else {
+		dst.out[from] = e
+		dst.outOrder.Add(from)
+	}
+	g.edges++
+	return e
+}
+
+// Edge returns the edge from from to to and whether it exists.
+func (g *Graph[N]) Edge(from, to N) (*Edge[N], bool) {
+	nd, ok := g.nodes[from]
+	if !ok {
+		return nil, false
+	}
+	e, ok := nd.out[to]
+	return e, ok
+}
+
+// HasEdge checks if there is an edge from from to to.
+func (g *Graph[N]) HasEdge(from, to N) bool {
+	_, ok := g.Edge(from, to)
+	return ok
+}
+
+// RemoveEdge removes the edge from from to to.
+//
+// Returns false if the edge was not present.
+func (g *Graph[N]) RemoveEdge(from, to N) bool {
+	if !g.HasEdge(from, to) {
+		return false
+	}
+	src, dst := g.nodes[from], g.nodes[to]
+	delete(src.out, to)
+	src.outOrder.Remove(to)
+	if g.directed {
+		delete(dst.in, from)
+		dst.inOrder.Remove(from)
+	} else {
+		delete(dst.out, from)
+		dst.outOrder.Remove(from)
+	}
+	g.edges--
+	return true
+}
+
+// Edges returns every edge, grouped by source node in node order. In an
+// undirected graph each edge is returned once.
+func (g *Graph[N]) Edges() []*Edge[N] {
+	edges := make([]*Edge[N], 0, g.edges)
+	seen := collections.NewSet[*Edge[N]]()
+	for _, n := range g.order.ToSlice() {
+		nd := g.nodes[n]
+		for _, to := range nd.outOrder.ToSlice() {
+			e := nd.out[to]
+			if !g.directed {
+				if seen.Contains(e) {
+					continue
+				}
+				seen.Add(e)
+			}
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// EdgeCount returns the number of edges.
+func (g *Graph[N]) EdgeCount() int {
+	return g.edges
+}
+
+// Successors returns the nodes that n has an edge to, in the order the edges
+// were added. In an undirected graph these are all neighbours of n.
+func (g *Graph[N]) Successors(n N) []N {
+	if nd, ok := g.nodes[n]; ok {
+		return nd.outOrder.ToSlice()
+	}
+	return nil
+}
+
+// Predecessors returns the nodes that have an edge to n, in the order the
+// edges were added. In an undirected graph these are all neighbours of n.
+func (g *Graph[N]) Predecessors(n N) []N {
+	nd, ok := g.nodes[n]
+	if !ok {
+		return nil
+	}
+	if g.directed {
+		return nd.inOrder.ToSlice()
+	}
+	return nd.outOrder.ToSlice()
+}
+
+// OutDegree returns the number of edges leaving n.
+func (g *Graph[N]) OutDegree(n N) int {
+	if nd, ok := g.nodes[n]; ok {
+		return nd.outOrder.Len()
+	}
+	return 0
+}
+
+// InDegree returns the number of edges entering n.
+func (g *Graph[N]) InDegree(n N) int {
+	nd, ok := g.nodes[n]
+	if !ok {
+		return 0
+	}
+	if g.directed {
+		return nd.inOrder.Len()
+	}
+	return nd.outOrder.Len()
+}
+
+// Clear removes all nodes and edges.
+func (g *Graph[N]) Clear() {
+	g.nodes = make(map[N]*node[N])
+	g.order.Clear()
+	g.edges = 0
+}
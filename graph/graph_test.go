@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph_Directed(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("a", "c", 2)
+	g.AddEdge("c", "b", 3)
+	g.AddNode("d")
+
+	if result := g.Nodes(); !reflect.DeepEqual(result, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Expected nodes in insertion order, but got %v", result)
+	}
+	if result := g.Predecessors("b"); !reflect.DeepEqual(result, []string{"a", "c"}) {
+		t.Errorf("Expected [a c], but got %v", result)
+	}
+	if g.HasEdge("b", "a") || !g.HasEdge("a", "b") {
+		t.Errorf("Expected edges to be one-way")
+	}
+
+	if e := g.AddEdge("a", "b", 5); e.Weight != 5 || g.EdgeCount() != 3 {
+		t.Errorf("Expected existing edge to be updated, but got weight %v and %v edges", e.Weight, g.EdgeCount())
+	}
+
+	g.RemoveNode("c")
+	if g.EdgeCount() != 1 || g.OutDegree("a") != 1 || g.InDegree("b") != 1 {
+		t.Errorf("Expected edges of removed node to be gone")
+	}
+	if g.Successors("c") != nil || g.HasNode("c") {
+		t.Errorf("Expected node c to be gone")
+	}
+}
+
+func TestGraph_Undirected(t *testing.T) {
+	g := NewUndirected[int]()
+	e := g.AddEdge(1, 2, 4)
+	g.AddEdge(2, 3, 1)
+
+	if other, ok := g.Edge(2, 1); !ok || other != e {
+		t.Errorf("Expected the same edge in both directions")
+	}
+	if result := g.Successors(2); !reflect.DeepEqual(result, []int{1, 3}) {
+		t.Errorf("Expected [1 3], but got %v", result)
+	}
+	if len(g.Edges()) != 2 || g.EdgeCount() != 2 {
+		t.Errorf("Expected each edge once, but got %v", g.Edges())
+	}
+
+	g.RemoveEdge(2, 1)
+	if g.HasEdge(1, 2) || g.EdgeCount() != 1 {
+		t.Errorf("Expected edge to be removed in both directions")
+	}
+}
+
+func TestGraph_Attributes(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddNode("build")
+	g.Attributes("build")["shape"] = "box"
+	g.AddEdge("build", "test", 1).Attributes["label"] = "then"
+
+	if g.Attributes("build")["shape"] != "box" {
+		t.Errorf("Expected node attribute to be stored")
+	}
+	if e, _ := g.Edge("build", "test"); e.Attributes["label"] != "then" {
+		t.Errorf("Expected edge attribute to be stored")
+	}
+	if g.Attributes("missing") != nil {
+		t.Errorf("Expected nil attributes for a missing node")
+	}
+}
+
+func TestGraph_DOT(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddNode("a")
+	g.Attributes("a")["shape"] = "box"
+	g.Attributes("a")["color"] = "red"
+	g.AddEdge("a", `b"c`, 1).Attributes["label"] = "1"
+
+	expected := "digraph \"deps\" {\n" +
+		"\t\"a\" [\"color\"=\"red\", \"shape\"=\"box\"];\n" +
+		"\t\"b\\\"c\";\n" +
+		"\t\"a\" -> \"b\\\"c\" [\"label\"=\"1\"];\n" +
+		"}\n"
+	if result := g.DOT("deps"); result != expected {
+		t.Errorf("Expected %q, but got %q", expected, result)
+	}
+
+	u := NewUndirected[int]()
+	u.AddEdge(1, 2, 1)
+	expected = "graph \"\" {\n\t\"1\";\n\t\"2\";\n\t\"1\" -- \"2\";\n}\n"
+	if result := u.DOT(""); result != expected {
+		t.Errorf("Expected %q, but got %q", expected, result)
+	}
+}
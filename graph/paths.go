@@ -0,0 +1,197 @@
+package graph
+
+import "github.com/kxrxh/goloom/collections"
+
+// Path is a walk through the graph together with the sum of its edge weights.
+type Path[N comparable] struct {
+	Nodes []N
+	Cost  float64
+}
+
+// ShortestPathTree holds the result of a single-source shortest path search.
+type ShortestPathTree[N comparable] struct {
+	source   N
+	distance map[N]float64
+	previous map[N]N
+}
+
+// Source returns the node the search started from.
+func (t *ShortestPathTree[N]) Source() N {
+	return t.source
+}
+
+// Distance returns the cost of the shortest path to n and whether n is
+// reachable.
+func (t *ShortestPathTree[N]) Distance(n N) (float64, bool) {
+	d, ok := t.distance[n]
+	return d, ok
+}
+
+// PathTo returns the shortest path to n and whether n is reachable.
+func (t *ShortestPathTree[N]) PathTo(n N) (Path[N], bool) {
+	cost, ok := t.distance[n]
+	if !ok {
+		return Path[N]{}, false
+	}
+	return Path[N]{Nodes: walkBack(t.previous, t.source, n), Cost: cost}, true
+}
+
+type queued[N comparable] struct {
+	node     N
+	priority float64
+}
+
+func lessQueued[N comparable](a, b queued[N]) bool {
+	return a.priority < b.priority
+}
+
+// Dijkstra computes the shortest paths from source to every reachable node.
+//
+// Returns ErrNodeNotFound if source is not in the graph and
+// ErrNegativeWeight if a reachable edge has a negative weight.
+func (g *Graph[N]) Dijkstra(source N) (*ShortestPathTree[N], error) {
+	if !g.HasNode(source) {
+		return nil, ErrNodeNotFound
+	}
+	tree := &ShortestPathTree[N]{
+		source:   source,
+		distance: map[N]float64{source: 0},
+		previous: make(map[N]N),
+	}
+	err := g.search(source, nil, func(N) float64 { return 0 }, tree.distance, tree.previous)
+	if err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// ShortestPath returns the cheapest path from from to to using Dijkstra's
+// algorithm, stopping as soon as to is settled.
+//
+// Returns ErrNodeNotFound, ErrNegativeWeight or ErrNoPath on failure.
+func (g *Graph[N]) ShortestPath(from, to N) (Path[N], error) {
+	return g.AStar(from, to, func(N) float64 { return 0 })
+}
+
+// AStar returns the cheapest path from from to to, guided by heuristic.
+//
+// heuristic estimates the remaining cost from a node to to. It must be zero
+// at to and never exceed the weight of an edge plus the estimate at its other
+// end, or the returned path may not be the cheapest.
+// Returns ErrNodeNotFound, ErrNegativeWeight or ErrNoPath on failure.
+func (g *Graph[N]) AStar(from, to N, heuristic func(n N) float64) (Path[N], error) {
+	if !g.HasNode(from, to) {
+		return Path[N]{}, ErrNodeNotFound
+	}
+	distance := map[N]float64{from: 0}
+	previous := make(map[N]N)
+	if err := g.search(from, &to, heuristic, distance, previous); err != nil {
+		return Path[N]{}, err
+	}
+	cost, ok := distance[to]
+	if !ok {
+		return Path[N]{}, ErrNoPath
+	}
+	return Path[N]{Nodes: walkBack(previous, from, to), Cost: cost}, nil
+}
+
+// search runs A* from source, or Dijkstra when heuristic is always zero,
+// filling distance and previous. It stops early once target is settled.
+func (g *Graph[N]) search(source N, target *N, heuristic func(n N) float64, distance map[N]float64, previous map[N]N) error {
+	pq := collections.NewPriorityQueue(lessQueued[N])
+	items := map[N]*collections.PriorityQueueItem[queued[N]]{
+		source: pq.Push(queued[N]{source, heuristic(source)}),
+	}
+	settled := make(map[N]bool)
+
+	for !pq.IsEmpty() {
+		n := pq.Pop().node
+		settled[n] = true
+		if target != nil && n == *target {
+			return nil
+		}
+		nd := g.nodes[n]
+		for _, s := range nd.outOrder.ToSlice() {
+			e := nd.out[s]
+			if e.Weight < 0 {
+				return ErrNegativeWeight
+			}
+			if settled[s] {
+				continue
+			}
+			d := distance[n] + e.Weight
+			if old, ok := distance[s]; ok && old <= d {
+				continue
+			}
+			distance[s] = d
+			previous[s] = n
+			next := queued[N]{s, d + heuristic(s)}
+			if it, ok := items[s]; ok {
+				pq.Update(it, next)
+			} else {
+				items[s] = pq.Push(next)
+			}
+		}
+	}
+	return nil
+}
+
+func walkBack[N comparable](previous map[N]N, source, target N) []N {
+	nodes := []N{target}
+	for n := target; n != source; {
+		n = previous[n]
+		nodes = append(nodes, n)
+	}
+	for l, r := 0, len(nodes)-1; l < r; l, r = l+1, r-1 {
+		nodes[l], nodes[r] = nodes[r], nodes[l]
+	}
+	return nodes
+}
+
+// MinimumSpanningTree returns the edges of a minimum spanning forest, built
+// with Prim's algorithm starting from each unvisited node in node order.
+//
+// Edges are returned in the order they were chosen, and their total weight
+// is returned alongside them.
+// Returns ErrDirected for a directed graph.
+func (g *Graph[N]) MinimumSpanningTree() ([]*Edge[N], float64, error) {
+	if g.directed {
+		return nil, 0, ErrDirected
+	}
+	type candidate struct {
+		edge *Edge[N]
+		to   N
+	}
+	pq := collections.NewPriorityQueue(func(a, b candidate) bool {
+		return a.edge.Weight < b.edge.Weight
+	})
+	visited := make(map[N]bool, len(g.nodes))
+	visit := func(n N) {
+		visited[n] = true
+		nd := g.nodes[n]
+		for _, s := range nd.outOrder.ToSlice() {
+			if !visited[s] {
+				pq.Push(candidate{nd.out[s], s})
+			}
+		}
+	}
+
+	var tree []*Edge[N]
+	total := 0.0
+	for _, root := range g.order.ToSlice() {
+		if visited[root] {
+			continue
+		}
+		visit(root)
+		for !pq.IsEmpty() {
+			c := pq.Pop()
+			if visited[c.to] {
+				continue
+			}
+			tree = append(tree, c.edge)
+			total += c.edge.Weight
+			visit(c.to)
+		}
+	}
+	return tree, total, nil
+}
@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestGraph_Dijkstra(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("a", "b", 7)
+	g.AddEdge("a", "c", 9)
+	g.AddEdge("a", "f", 14)
+	g.AddEdge("b", "c", 10)
+	g.AddEdge("b", "d", 15)
+	g.AddEdge("c", "d", 11)
+	g.AddEdge("c", "f", 2)
+	g.AddEdge("d", "e", 6)
+	g.AddEdge("f", "e", 9)
+	g.AddNode("z")
+
+	tree, err := g.Dijkstra("a")
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	if d, ok := tree.Distance("e"); !ok || d != 20 {
+		t.Errorf("Expected distance 20, but got %v %v", d, ok)
+	}
+	if p, _ := tree.PathTo("e"); !reflect.DeepEqual(p.Nodes, []string{"a", "c", "f", "e"}) {
+		t.Errorf("Expected [a c f e], but got %v", p.Nodes)
+	}
+	if _, ok := tree.PathTo("z"); ok {
+		t.Errorf("Expected z to be unreachable")
+	}
+
+	p, err := g.ShortestPath("a", "d")
+	if err != nil || p.Cost != 20 || !reflect.DeepEqual(p.Nodes, []string{"a", "c", "d"}) {
+		t.Errorf("Expected [a c d] with cost 20, but got %v (%v)", p, err)
+	}
+	if _, err := g.ShortestPath("a", "z"); err != ErrNoPath {
+		t.Errorf("Expected ErrNoPath, but got %v", err)
+	}
+	if _, err := g.ShortestPath("a", "missing"); err != ErrNodeNotFound {
+		t.Errorf("Expected ErrNodeNotFound, but got %v", err)
+	}
+
+	g.AddEdge("e", "a", -1)
+	if _, err := g.Dijkstra("a"); err != ErrNegativeWeight {
+		t.Errorf("Expected ErrNegativeWeight, but got %v", err)
+	}
+}
+
+func TestGraph_AStar(t *testing.T) {
+	type cell struct{ x, y int }
+	const size = 10
+	g := NewUndirected[cell]()
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			// A wall at x == 5 with a single gap at y == 8.
+			if x == 5 && y != 8 {
+				continue
+			}
+			if x+1 < size && (x+1 != 5 || y == 8) {
+				g.AddEdge(cell{x, y}, cell{x + 1, y}, 1)
+			}
+			if y+1 < size && x != 5 {
+				g.AddEdge(cell{x, y}, cell{x, y + 1}, 1)
+			}
+		}
+	}
+
+	goal := cell{9, 0}
+	manhattan := func(c cell) float64 {
+		return math.Abs(float64(c.x-goal.x)) + math.Abs(float64(c.y-goal.y))
+	}
+	p, err := g.AStar(cell{0, 0}, goal, manhattan)
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+
+	reference, _ := g.ShortestPath(cell{0, 0}, goal)
+	if p.Cost != reference.Cost || p.Cost != 25 {
+		t.Errorf("Expected cost 25, but got %v (Dijkstra %v)", p.Cost, reference.Cost)
+	}
+	if len(p.Nodes) != int(p.Cost)+1 || p.Nodes[0] != (cell{0, 0}) || p.Nodes[len(p.Nodes)-1] != goal {
+		t.Errorf("Unexpected path %v", p.Nodes)
+	}
+}
+
+func TestGraph_MinimumSpanningTree(t *testing.T) {
+	g := NewUndirected[string]()
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "h", 8)
+	g.AddEdge("b", "c", 8)
+	g.AddEdge("b", "h", 11)
+	g.AddEdge("c", "d", 7)
+	g.AddEdge("c", "f", 4)
+	g.AddEdge("c", "i", 2)
+	g.AddEdge("d", "e", 9)
+	g.AddEdge("d", "f", 14)
+	g.AddEdge("e", "f", 10)
+	g.AddEdge("f", "g", 2)
+	g.AddEdge("g", "h", 1)
+	g.AddEdge("g", "i", 6)
+	g.AddEdge("h", "i", 7)
+	g.AddEdge("x", "y", 3)
+
+	edges, total, err := g.MinimumSpanningTree()
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	if total != 40 || len(edges) != 9 {
+		t.Errorf("Expected a forest of 9 edges weighing 40, but got %v edges weighing %v", len(edges), total)
+	}
+
+	if _, _, err := NewDirected[int]().MinimumSpanningTree(); err != ErrDirected {
+		t.Errorf("Expected ErrDirected, but got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CycleError is returned by TopologicalSort when the graph has a cycle.
+type CycleError[N comparable] struct {
+	// Cycle lists the nodes of one cycle in edge order. The edge from the
+	// last node back to the first closes the cycle.
+	Cycle []N
+}
+
+func (e *CycleError[N]) Error() string {
+	parts := make([]string, 0, len(e.Cycle)+1)
+	for _, n := range e.Cycle {
+		parts = append(parts, fmt.Sprint(n))
+	}
+	if len(e.Cycle) > 0 {
+		parts = append(parts, fmt.Sprint(e.Cycle[0]))
+	}
+	return "graph: cycle detected: " + strings.Join(parts, " -> ")
+}
+
+// BFS visits the nodes reachable from start in breadth-first order.
+//
+// fn receives each node with its distance in edges from start; returning
+// false stops the traversal. BFS does nothing if start is not in the graph.
+func (g *Graph[N]) BFS(start N, fn func(n N, depth int) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+	visited := map[N]bool{start: true}
+	level := []N{start}
+	for depth := 0; len(level) > 0; depth++ {
+		var next []N
+		for _, n := range level {
+			if !fn(n, depth) {
+				return
+			}
+			for _, s := range g.nodes[n].outOrder.ToSlice() {
+				if !visited[s] {
+					visited[s] = true
+					next = append(next, s)
+				}
+			}
+		}
+		level = next
+	}
+}
+
+// DFS visits the nodes reachable from start in depth-first preorder,
+// following edges in the order they were added.
+//
+// fn receives each node with the depth of the search tree it was reached at;
+// returning false stops the traversal. DFS does nothing if start is not in
+// the graph.
+func (g *Graph[N]) DFS(start N, fn func(n N, depth int) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+	type frame struct {
+		node  N
+		depth int
+	}
+	visited := make(map[N]bool)
+	stack := []frame{{start, 0}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[f.node] {
+			continue
+		}
+		visited[f.node] = true
+		if !fn(f.node, f.depth) {
+			return
+		}
+		successors := g.nodes[f.node].outOrder.ToSlice()
+		for i := len(successors) - 1; i >= 0; i-- {
+			if !visited[successors[i]] {
+				stack = append(stack, frame{successors[i], f.depth + 1})
+			}
+		}
+	}
+}
+
+// TopologicalSort orders the nodes so that every edge points forward.
+//
+// Among nodes that could come next, the one added first is chosen, so the
+// order is stable across runs.
+// Returns ErrUndirected for an undirected graph and a *CycleError holding
+// one of the cycles if there is no such order.
+func (g *Graph[N]) TopologicalSort() ([]N, error) {
+	if !g.directed {
+		return nil, ErrUndirected
+	}
+	indegree := make(map[N]int, len(g.nodes))
+	var queue []N
+	for _, n := range g.order.ToSlice() {
+		indegree[n] = g.nodes[n].inOrder.Len()
+		if indegree[n] == 0 {
+			queue = append(queue, n)
+		}
+	}
+
+	sorted := make([]N, 0, len(g.nodes))
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		sorted = append(sorted, n)
+		for _, s := range g.nodes[n].outOrder.ToSlice() {
+			indegree[s]--
+			if indegree[s] == 0 {
+				queue = append(queue, s)
+			}
+		}
+	}
+	if len(sorted) == len(g.nodes) {
+		return sorted, nil
+	}
+	return nil, &CycleError[N]{Cycle: g.findCycle(indegree)}
+}
+
+// findCycle returns a cycle among the nodes that TopologicalSort could not
+// place. Each of them still has a predecessor that was not placed either, so
+// walking predecessors must eventually revisit a node.
+func (g *Graph[N]) findCycle(indegree map[N]int) []N {
+	var cur N
+	for _, n := range g.order.ToSlice() {
+		if indegree[n] > 0 {
+			cur = n
+			break
+		}
+	}
+	index := make(map[N]int)
+	var path []N
+	for {
+		if i, ok := index[cur]; ok {
+			cycle := path[i:]
+			for l, r := 0, len(cycle)-1; l < r; l, r = l+1, r-1 {
+				cycle[l], cycle[r] = cycle[r], cycle[l]
+			}
+			return cycle
+		}
+		index[cur] = len(path)
+		path = append(path, cur)
+		for _, p := range g.nodes[cur].inOrder.ToSlice() {
+			if indegree[p] > 0 {
+				cur = p
+				break
+			}
+		}
+	}
+}
+
+// StronglyConnectedComponents splits the graph into maximal groups of nodes
+// that can all reach each other, using Tarjan's algorithm. In an undirected
+// graph these are the connected components.
+//
+// Components are returned in reverse topological order: no component has an
+// edge to a component that comes after it. Nodes keep the order in which the
+// search reached them.
+func (g *Graph[N]) StronglyConnectedComponents() [][]N {
+	var (
+		index      = make(map[N]int, len(g.nodes))
+		lowlink    = make(map[N]int, len(g.nodes))
+		onStack    = make(map[N]bool, len(g.nodes))
+		stack      []N
+		components [][]N
+		connect    func(n N)
+	)
+	connect = func(n N) {
+		index[n] = len(index)
+		lowlink[n] = index[n]
+		stack = append(stack, n)
+		onStack[n] = true
+
+		for _, s := range g.nodes[n].outOrder.ToSlice() {
+			if _, seen := index[s]; !seen {
+				connect(s)
+				lowlink[n] = min(lowlink[n], lowlink[s])
+			} else if onStack[s] {
+				lowlink[n] = min(lowlink[n], index[s])
+			}
+		}
+
+		if lowlink[n] == index[n] {
+			i := len(stack) - 1
+			for stack[i] != n {
+				i--
+			}
+			component := append([]N{}, stack[i:]...)
+			for _, m := range component {
+				onStack[m] = false
+			}
+			stack = stack[:i]
+			components = append(components, component)
+		}
+	}
+
+	for _, n := range g.order.ToSlice() {
+		if _, seen := index[n]; !seen {
+			connect(n)
+		}
+	}
+	return components
+}
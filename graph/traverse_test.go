@@ -0,0 +1,125 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGraph_BFS(t *testing.T) {
+	g := NewUndirected[int]()
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(2, 4, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(4, 5, 1)
+
+	var order, depths []int
+	g.BFS(1, func(n, depth int) bool {
+		order = append(order, n)
+		depths = append(depths, depth)
+		return true
+	})
+	if !reflect.DeepEqual(order, []int{1, 2, 3, 4, 5}) || !reflect.DeepEqual(depths, []int{0, 1, 1, 2, 3}) {
+		t.Errorf("Unexpected BFS order %v with depths %v", order, depths)
+	}
+
+	count := 0
+	g.BFS(1, func(int, int) bool {
+		count++
+		return count < 2
+	})
+	if count != 2 {
+		t.Errorf("Expected BFS to stop after 2 nodes, but visited %v", count)
+	}
+}
+
+func TestGraph_DFS(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("a", "e", 1)
+	g.AddEdge("b", "c", 1)
+	g.AddEdge("b", "d", 1)
+	g.AddEdge("d", "a", 1)
+	g.AddEdge("e", "c", 1)
+
+	var order []string
+	g.DFS("a", func(n string, _ int) bool {
+		order = append(order, n)
+		return true
+	})
+	if !reflect.DeepEqual(order, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("Unexpected DFS order %v", order)
+	}
+
+	g.DFS("missing", func(string, int) bool {
+		t.Errorf("Expected no visit for a missing start node")
+		return true
+	})
+}
+
+func TestGraph_TopologicalSort(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddNode("deploy")
+	g.AddEdge("fetch", "build", 1)
+	g.AddEdge("build", "test", 1)
+	g.AddEdge("build", "lint", 1)
+	g.AddEdge("test", "deploy", 1)
+	g.AddEdge("lint", "deploy", 1)
+
+	order, err := g.TopologicalSort()
+	expected := []string{"fetch", "build", "test", "lint", "deploy"}
+	if err != nil || !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected %v, but got %v (%v)", expected, order, err)
+	}
+
+	g.AddEdge("deploy", "build", 1)
+	_, err = g.TopologicalSort()
+	var cycle *CycleError[string]
+	if !errors.As(err, &cycle) {
+		t.Fatalf("Expected a CycleError, but got %v", err)
+	}
+	for i, n := range cycle.Cycle {
+		if next := cycle.Cycle[(i+1)%len(cycle.Cycle)]; !g.HasEdge(n, next) {
+			t.Errorf("Reported cycle %v has no edge %v -> %v", cycle.Cycle, n, next)
+		}
+	}
+	if len(cycle.Cycle) != 3 {
+		t.Errorf("Expected a cycle of 3 nodes, but got %v", cycle.Cycle)
+	}
+
+	if _, err := NewUndirected[int]().TopologicalSort(); err != ErrUndirected {
+		t.Errorf("Expected ErrUndirected, but got %v", err)
+	}
+}
+
+func TestCycleError_Error(t *testing.T) {
+	err := &CycleError[int]{Cycle: []int{1, 2, 3}}
+	if expected := "graph: cycle detected: 1 -> 2 -> 3 -> 1"; err.Error() != expected {
+		t.Errorf("Expected %v, but got %v", expected, err.Error())
+	}
+}
+
+func TestGraph_StronglyConnectedComponents(t *testing.T) {
+	g := NewDirected[int]()
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 1, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(4, 5, 1)
+	g.AddEdge(5, 4, 1)
+	g.AddNode(6)
+
+	expected := [][]int{{4, 5}, {1, 2, 3}, {6}}
+	if result := g.StronglyConnectedComponents(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	u := NewUndirected[int]()
+	u.AddEdge(1, 2, 1)
+	u.AddEdge(3, 4, 1)
+	expected = [][]int{{1, 2}, {3, 4}}
+	if result := u.StronglyConnectedComponents(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected connected components %v, but got %v", expected, result)
+	}
+}
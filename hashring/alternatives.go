@@ -0,0 +1,151 @@
+package hashring
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+// JumpHash maps key to a bucket in [0, buckets) with Lamping and Veach's
+// jump consistent hash. It needs no memory and moves only 1/buckets of the
+// keys when a bucket is added, but buckets can only be added or removed at
+// the end.
+//
+// Returns -1 if buckets is less than 1.
+func JumpHash(key uint64, buckets int) int {
+	if buckets < 1 {
+		return -1
+	}
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
+// JumpHashString hashes key with DefaultHash and passes it to JumpHash.
+func JumpHashString(key string, buckets int) int {
+	return JumpHash(DefaultHash([]byte(key)), buckets)
+}
+
+type Rendezvous struct {
+	mu      sync.RWMutex
+	hash    HashFunc
+	members collections.Set[string]
+	weights map[string]float64
+}
+
+// NewRendezvous creates an empty rendezvous (highest random weight) hash.
+// Every key goes to the node that scores highest for it, so removing a node
+// moves only its own keys. Lookups cost O(nodes). A nil hash means
+// DefaultHash.
+//
+// Returns a pointer to the new Rendezvous.
+func NewRendezvous(hash HashFunc) *Rendezvous {
+	if hash == nil {
+		hash = DefaultHash
+	}
+	return &Rendezvous{hash: hash, members: collections.NewSet[string](), weights: make(map[string]float64)}
+}
+
+// Add adds nodes with weight 1.
+func (r *Rendezvous) Add(nodes ...string) {
+	for _, n := range nodes {
+		r.AddWeighted(n, 1)
+	}
+}
+
+// AddWeighted adds node, or changes its weight, so that it receives a share
+// of keys proportional to weight.
+//
+// It panics if weight is not positive.
+func (r *Rendezvous) AddWeighted(node string, weight float64) {
+	if weight <= 0 {
+		panic("hashring: weight must be positive")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.members.Add(node)
+	r.weights[node] = weight
+}
+
+// Remove removes node.
+//
+// Returns false if node was not a member.
+func (r *Rendezvous) Remove(node string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.members.Contains(node) {
+		return false
+	}
+	r.members.Remove(node)
+	delete(r.weights, node)
+	return true
+}
+
+// Get returns the node for key and false if there are no nodes.
+func (r *Rendezvous) Get(key string) (string, bool) {
+	nodes := r.GetN(key, 1)
+	if len(nodes) == 0 {
+		return "", false
+	}
+	return nodes[0], true
+}
+
+// GetN returns up to n nodes for key, best first.
+func (r *Rendezvous) GetN(key string, n int) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	n = min(n, r.members.Len())
+	if n <= 0 {
+		return nil
+	}
+	type scored struct {
+		node  string
+		score float64
+	}
+	all := make([]scored, 0, r.members.Len())
+	for _, node := range r.members.ToSlice() {
+		all = append(all, scored{node, r.score(key, node)})
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].score != all[j].score {
+			return all[i].score > all[j].score
+		}
+		return all[i].node < all[j].node
+	})
+
+	nodes := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		nodes = append(nodes, all[i].node)
+	}
+	return nodes
+}
+
+// Members returns the nodes in sorted order.
+func (r *Rendezvous) Members() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	members := r.members.ToSlice()
+	sort.Strings(members)
+	return members
+}
+
+// Len returns the number of nodes.
+func (r *Rendezvous) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.members.Len()
+}
+
+// score is the weighted rendezvous score -w/ln(u), where u is the hash of key
+// and node mapped into (0, 1).
+func (r *Rendezvous) score(key, node string) float64 {
+	h := r.hash([]byte(node + "\x00" + key))
+	u := (float64(h>>11) + 0.5) / (1 << 53)
+	return -r.weights[node] / math.Log(u)
+}
@@ -0,0 +1,84 @@
+package hashring
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJumpHash(t *testing.T) {
+	if JumpHash(1, 0) != -1 {
+		t.Errorf("Expected -1 without buckets")
+	}
+	// Known values from the reference implementation.
+	if JumpHash(0, 1) != 0 || JumpHash(0xdeadbeef, 1) != 0 {
+		t.Errorf("Expected every key in the only bucket")
+	}
+
+	ks := keys(20000)
+	moved := 0
+	counts := make([]int, 11)
+	for _, k := range ks {
+		before, after := JumpHashString(k, 10), JumpHashString(k, 11)
+		counts[after]++
+		if before != after {
+			moved++
+			if after != 10 {
+				t.Fatalf("Key %v moved from %v to %v instead of the new bucket", k, before, after)
+			}
+		}
+	}
+	if share := float64(moved) / float64(len(ks)); share < 0.07 || share > 0.11 {
+		t.Errorf("Expected about 9%% of keys to move, but %.1f%% did", share*100)
+	}
+	for b, c := range counts {
+		if c < 1500 || c > 2150 {
+			t.Errorf("Bucket %v holds %v keys, far from the mean 1818", b, c)
+		}
+	}
+}
+
+func TestRendezvous(t *testing.T) {
+	r := NewRendezvous(nil)
+	if _, ok := r.Get("k"); ok {
+		t.Errorf("Expected no node without members")
+	}
+	r.Add("a", "b", "c", "d")
+
+	ks := keys(20000)
+	before := make(map[string]string, len(ks))
+	for _, k := range ks {
+		before[k], _ = r.Get(k)
+	}
+
+	// Removing a node only moves its own keys.
+	r.Remove("c")
+	for _, k := range ks {
+		after, _ := r.Get(k)
+		if before[k] != "c" && after != before[k] {
+			t.Fatalf("Key %v moved from %v to %v", k, before[k], after)
+		}
+	}
+
+	top := r.GetN("k", 3)
+	if len(top) != 3 || top[0] != func() string { n, _ := r.Get("k"); return n }() {
+		t.Errorf("Expected GetN to start with the owner, but got %v", top)
+	}
+	if len(r.GetN("k", 10)) != 3 || r.GetN("k", 0) != nil || r.GetN("k", -1) != nil {
+		t.Errorf("Expected GetN to be capped by the number of nodes")
+	}
+	if !reflect.DeepEqual(r.Members(), []string{"a", "b", "d"}) || r.Len() != 3 {
+		t.Errorf("Unexpected members %v", r.Members())
+	}
+
+	w := NewRendezvous(nil)
+	w.Add("small")
+	w.AddWeighted("big", 3)
+	counts := make(map[string]int)
+	for _, k := range ks {
+		n, _ := w.Get(k)
+		counts[n]++
+	}
+	if ratio := float64(counts["big"]) / float64(counts["small"]); ratio < 2.6 || ratio > 3.4 {
+		t.Errorf("Expected about 3 times as many keys on big, but got %v", counts)
+	}
+}
@@ -0,0 +1,97 @@
+package hashring
+
+import (
+	"errors"
+	"math"
+	"sync"
+)
+
+// ErrNoCapacity is returned by BoundedRing.Acquire when the ring is empty.
+var ErrNoCapacity = errors.New("hashring: no node has capacity")
+
+type BoundedRing struct {
+	mu         sync.Mutex
+	ring       *Ring
+	loadFactor float64
+	loads      map[string]int
+	total      int
+}
+
+// NewBounded creates an empty ring with bounded loads: no node is assigned
+// more than loadFactor times the average load, rounded up. A key whose owner
+// is full goes to the next node clockwise with room, so keys still move
+// little when membership changes.
+//
+// It panics if loadFactor is not greater than 1.
+// Returns a pointer to the new BoundedRing.
+func NewBounded(config Config, loadFactor float64) *BoundedRing {
+	if loadFactor <= 1 {
+		panic("hashring: load factor must be greater than 1")
+	}
+	return &BoundedRing{ring: New(config), loadFactor: loadFactor, loads: make(map[string]int)}
+}
+
+// Ring returns the underlying ring, which is used to add and remove nodes.
+func (b *BoundedRing) Ring() *Ring {
+	return b.ring
+}
+
+// Acquire assigns key to a node and counts it against that node's load.
+// Call Release with the node once the key is no longer served.
+//
+// Returns ErrNoCapacity if the ring is empty.
+func (b *BoundedRing) Acquire(key string) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	node, ok := b.pick(key)
+	if !ok {
+		return "", ErrNoCapacity
+	}
+	b.loads[node]++
+	b.total++
+	return node, nil
+}
+
+// Get returns the node Acquire would choose for key, without counting it.
+func (b *BoundedRing) Get(key string) (string, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.pick(key)
+}
+
+// Release takes one key off the load of node.
+func (b *BoundedRing) Release(node string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.loads[node] > 0 {
+		b.loads[node]--
+		b.total--
+	}
+}
+
+// Load returns the number of keys acquired on node and not yet released.
+func (b *BoundedRing) Load(node string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.loads[node]
+}
+
+// pick walks clockwise from the owner of key to the first node under the
+// load limit. The limit always leaves room on at least one node.
+func (b *BoundedRing) pick(key string) (string, bool) {
+	r := b.ring
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.points) == 0 {
+		return "", false
+	}
+	limit := int(math.Ceil(b.loadFactor * float64(b.total+1) / float64(r.members.Len())))
+	start := r.search(key)
+	for i := 0; i < len(r.points); i++ {
+		node := r.points[(start+i)%len(r.points)].node
+		if b.loads[node] < limit {
+			return node, true
+		}
+	}
+	return r.points[start].node, true
+}
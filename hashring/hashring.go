@@ -0,0 +1,222 @@
+package hashring
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+// HashFunc maps bytes to a position on the ring.
+type HashFunc func(data []byte) uint64
+
+// DefaultHash is 64-bit FNV-1a followed by a finalizer that spreads similar
+// inputs, such as "node#1" and "node#2", across the whole ring.
+func DefaultHash(data []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(data)
+	return mix(h.Sum64())
+}
+
+// mix is the splitmix64 finalizer.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+type Config struct {
+	// VirtualNodes is the number of ring positions per unit of weight. More
+	// positions spread keys more evenly. The default is 100.
+	VirtualNodes int
+	// Hash defaults to DefaultHash.
+	Hash HashFunc
+}
+
+type point struct {
+	hash uint64
+	node string
+}
+
+type Ring struct {
+	mu      sync.RWMutex
+	config  Config
+	members collections.Set[string]
+	weights map[string]int
+	points  []point
+}
+
+// New creates an empty consistent-hash ring.
+//
+// Returns a pointer to the new Ring.
+func New(config Config) *Ring {
+	if config.VirtualNodes <= 0 {
+		config.VirtualNodes = 100
+	}
+	if config.Hash == nil {
+		config.Hash = DefaultHash
+	}
+	return &Ring{
+		config:  config,
+		members: collections.NewSet[string](),
+		weights: make(map[string]int),
+	}
+}
+
+// Add adds nodes with weight 1. Nodes that are already members keep their
+// weight.
+func (r *Ring) Add(nodes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, n := range nodes {
+		if !r.members.Contains(n) {
+			r.add(n, 1)
+		}
+	}
+	r.sort()
+}
+
+// AddWeighted adds node with weight times as many ring positions as a node
+// of weight 1, so it receives about weight times as many keys. Adding an
+// existing member changes its weight.
+//
+// It panics if weight is less than 1.
+func (r *Ring) AddWeighted(node string, weight int) {
+	if weight < 1 {
+		panic("hashring: weight must be positive")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.members.Contains(node) {
+		r.remove(node)
+	}
+	r.add(node, weight)
+	r.sort()
+}
+
+// Remove removes node from the ring. Only the keys it owned move, each to
+// the next node clockwise.
+//
+// Returns false if node was not a member.
+func (r *Ring) Remove(node string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.members.Contains(node) {
+		return false
+	}
+	r.remove(node)
+	return true
+}
+
+// Get returns the node that owns key and false if the ring is empty.
+func (r *Ring) Get(key string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.points) == 0 {
+		return "", false
+	}
+	return r.points[r.search(key)].node, true
+}
+
+// GetN returns up to n distinct nodes for key: the owner followed by the
+// next nodes clockwise, which is where replicas of key belong.
+func (r *Ring) GetN(key string, n int) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	n = min(n, r.members.Len())
+	if n <= 0 {
+		return nil
+	}
+	nodes := make([]string, 0, n)
+	seen := collections.NewSetOfSize[string](uint64(n))
+	for i, start := 0, r.search(key); len(nodes) < n; i++ {
+		p := r.points[(start+i)%len(r.points)]
+		if !seen.Contains(p.node) {
+			seen.Add(p.node)
+			nodes = append(nodes, p.node)
+		}
+	}
+	return nodes
+}
+
+// Members returns the nodes of the ring in sorted order.
+func (r *Ring) Members() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	members := r.members.ToSlice()
+	sort.Strings(members)
+	return members
+}
+
+// Contains checks if all the given nodes are members.
+func (r *Ring) Contains(nodes ...string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.members.Contains(nodes...)
+}
+
+// Weight returns the weight of node, or 0 if it is not a member.
+func (r *Ring) Weight(node string) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.weights[node]
+}
+
+// Len returns the number of nodes.
+func (r *Ring) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.members.Len()
+}
+
+// IsEmpty checks if the ring has no nodes.
+func (r *Ring) IsEmpty() bool {
+	return r.Len() == 0
+}
+
+func (r *Ring) add(node string, weight int) {
+	r.members.Add(node)
+	r.weights[node] = weight
+	for i := 0; i < weight*r.config.VirtualNodes; i++ {
+		r.points = append(r.points, point{r.config.Hash([]byte(node + "#" + strconv.Itoa(i))), node})
+	}
+}
+
+func (r *Ring) remove(node string) {
+	r.members.Remove(node)
+	delete(r.weights, node)
+	kept := r.points[:0]
+	for _, p := range r.points {
+		if p.node != node {
+			kept = append(kept, p)
+		}
+	}
+	r.points = kept
+}
+
+// sort orders the points by hash, breaking ties by node so the ring does not
+// depend on the order nodes were added in.
+func (r *Ring) sort() {
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash != r.points[j].hash {
+			return r.points[i].hash < r.points[j].hash
+		}
+		return r.points[i].node < r.points[j].node
+	})
+}
+
+// search returns the index of the first point at or after the hash of key,
+// wrapping around to 0.
+func (r *Ring) search(key string) int {
+	h := r.config.Hash([]byte(key))
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		return 0
+	}
+	return i
+}
@@ -0,0 +1,150 @@
+package hashring
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func keys(n int) []string {
+	result := make([]string, n)
+	for i := range result {
+		result[i] = "key-" + strconv.Itoa(i)
+	}
+	return result
+}
+
+func assignments(r *Ring, ks []string) map[string]string {
+	owners := make(map[string]string, len(ks))
+	for _, k := range ks {
+		owners[k], _ = r.Get(k)
+	}
+	return owners
+}
+
+func TestRing_GetAndMembers(t *testing.T) {
+	r := New(Config{})
+	if _, ok := r.Get("k"); ok || !r.IsEmpty() {
+		t.Errorf("Expected an empty ring to own nothing")
+	}
+
+	r.Add("c", "a", "b", "a")
+	if result := r.Members(); !reflect.DeepEqual(result, []string{"a", "b", "c"}) || r.Len() != 3 {
+		t.Errorf("Expected [a b c], but got %v", result)
+	}
+
+	owner, _ := r.Get("user:42")
+	if again, _ := r.Get("user:42"); again != owner {
+		t.Errorf("Expected lookups to be stable")
+	}
+
+	other := New(Config{})
+	other.Add("b", "c", "a")
+	if o, _ := other.Get("user:42"); o != owner {
+		t.Errorf("Expected the owner not to depend on insertion order")
+	}
+
+	if !r.Remove("b") || r.Remove("b") || r.Contains("b") {
+		t.Errorf("Expected exactly one successful removal")
+	}
+}
+
+func TestRing_MinimalMovement(t *testing.T) {
+	ks := keys(20000)
+	r := New(Config{})
+	r.Add("n1", "n2", "n3", "n4")
+	before := assignments(r, ks)
+
+	// Adding a fifth node only moves keys onto it, about a fifth of them.
+	r.Add("n5")
+	after := assignments(r, ks)
+	moved := 0
+	for _, k := range ks {
+		if before[k] != after[k] {
+			moved++
+			if after[k] != "n5" {
+				t.Fatalf("Key %v moved from %v to %v instead of the new node", k, before[k], after[k])
+			}
+		}
+	}
+	if share := float64(moved) / float64(len(ks)); share < 0.12 || share > 0.28 {
+		t.Errorf("Expected about 20%% of keys to move, but %.1f%% did", share*100)
+	}
+
+	// Removing it again moves exactly those keys back.
+	r.Remove("n5")
+	if result := assignments(r, ks); !reflect.DeepEqual(result, before) {
+		t.Errorf("Expected removal to restore the previous assignment")
+	}
+}
+
+func TestRing_Weighted(t *testing.T) {
+	ks := keys(30000)
+	r := New(Config{})
+	r.Add("small")
+	r.AddWeighted("big", 3)
+	if r.Weight("big") != 3 || r.Weight("small") != 1 || r.Weight("none") != 0 {
+		t.Errorf("Unexpected weights")
+	}
+
+	counts := make(map[string]int)
+	for _, owner := range assignments(r, ks) {
+		counts[owner]++
+	}
+	if ratio := float64(counts["big"]) / float64(counts["small"]); ratio < 2.4 || ratio > 3.6 {
+		t.Errorf("Expected about 3 times as many keys on big, but got %v", counts)
+	}
+}
+
+func TestRing_GetN(t *testing.T) {
+	r := New(Config{})
+	r.Add("a", "b", "c", "d")
+
+	replicas := r.GetN("order:7", 3)
+	owner, _ := r.Get("order:7")
+	if len(replicas) != 3 || replicas[0] != owner {
+		t.Errorf("Expected 3 replicas starting with the owner %v, but got %v", owner, replicas)
+	}
+	seen := map[string]bool{}
+	for _, n := range replicas {
+		if seen[n] {
+			t.Errorf("Expected distinct replicas, but got %v", replicas)
+		}
+		seen[n] = true
+	}
+	if len(r.GetN("order:7", 10)) != 4 || r.GetN("order:7", 0) != nil {
+		t.Errorf("Expected GetN to be capped by the number of nodes")
+	}
+
+	// When the owner leaves, the first replica takes over.
+	r.Remove(owner)
+	if next, _ := r.Get("order:7"); next != replicas[1] {
+		t.Errorf("Expected %v to take over, but got %v", replicas[1], next)
+	}
+}
+
+func TestBoundedRing(t *testing.T) {
+	b := NewBounded(Config{}, 1.25)
+	if _, err := b.Acquire("k"); err != ErrNoCapacity {
+		t.Errorf("Expected ErrNoCapacity, but got %v", err)
+	}
+	b.Ring().Add("a", "b", "c", "d")
+
+	for _, k := range keys(1000) {
+		if _, err := b.Acquire(k); err != nil {
+			t.Fatalf("Expected nil, but got %v", err)
+		}
+	}
+	for _, n := range b.Ring().Members() {
+		if load := b.Load(n); load > 313 {
+			t.Errorf("Node %v has load %v above the bound 313", n, load)
+		}
+	}
+
+	node, _ := b.Get("extra")
+	before := b.Load(node)
+	b.Release(node)
+	if b.Load(node) != before-1 {
+		t.Errorf("Expected Release to lower the load")
+	}
+}
@@ -0,0 +1,273 @@
+package history
+
+import (
+	"errors"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+var (
+	// ErrNothingToUndo is returned by Undo when the undo stack is empty.
+	ErrNothingToUndo = errors.New("history: nothing to undo")
+	// ErrNothingToRedo is returned by Redo when the redo stack is empty.
+	ErrNothingToRedo = errors.New("history: nothing to redo")
+	// ErrTransactionOpen is returned by Undo and Redo while a transaction is open.
+	ErrTransactionOpen = errors.New("history: transaction is open")
+	// ErrNoTransaction is returned by Commit and Rollback without a matching Begin.
+	ErrNoTransaction = errors.New("history: no open transaction")
+)
+
+// Command is a reversible action.
+//
+// Do applies the action and Undo reverts it. Consecutive commands with the
+// same non-empty MergeKey are coalesced into a single undo step, which is
+// how typing runs or slider drags become one entry in the undo menu.
+// Do and Undo must both be set.
+type Command struct {
+	Label    string
+	Do       func() error
+	Undo     func() error
+	MergeKey string
+}
+
+// entry is one undo step. It holds one command, several coalesced commands
+// or the commands of a transaction, in the order they were applied.
+type entry struct {
+	label    string
+	mergeKey string
+	commands []Command
+}
+
+type History struct {
+	undo     collections.Stack[*entry]
+	redo     collections.Stack[*entry]
+	maxDepth int
+	tx       *entry
+	txDepth  int
+	sealed   bool
+}
+
+// New creates an empty History.
+//
+// maxDepth limits the number of undo steps that are kept; the oldest steps
+// are dropped first. A maxDepth of 0 means the history is unbounded.
+// Returns a pointer to the new History.
+func New(maxDepth int) *History {
+	return &History{
+		undo:     collections.NewStack[*entry](),
+		redo:     collections.NewStack[*entry](),
+		maxDepth: maxDepth,
+	}
+}
+
+// Execute applies the command and records it.
+//
+// If Do fails, nothing is recorded and the error is returned.
+// Recording a new command clears the redo stack.
+func (h *History) Execute(cmd Command) error {
+	if err := cmd.Do(); err != nil {
+		return err
+	}
+	h.Record(cmd)
+	return nil
+}
+
+// Record adds a command that has already been applied by the caller.
+//
+// Recording a new command clears the redo stack.
+func (h *History) Record(cmd Command) {
+	h.redo.Clear()
+	if h.tx != nil {
+		h.tx.commands = append(h.tx.commands, cmd)
+		return
+	}
+
+	top := h.undo.Peek()
+	if !h.sealed && top != nil && cmd.MergeKey != "" && top.mergeKey == cmd.MergeKey {
+		top.commands = append(top.commands, cmd)
+		return
+	}
+	h.push(&entry{label: cmd.Label, mergeKey: cmd.MergeKey, commands: []Command{cmd}})
+}
+
+// Seal stops the next command from being coalesced with the last undo step,
+// even if both share a MergeKey.
+func (h *History) Seal() {
+	h.sealed = true
+}
+
+// Undo reverts the most recent undo step and moves it to the redo stack.
+//
+// If a command fails to undo, the commands of the step that were already
+// reverted are applied again, the step stays on the undo stack and the error
+// is returned.
+func (h *History) Undo() error {
+	if h.tx != nil {
+		return ErrTransactionOpen
+	}
+	if h.undo.IsEmpty() {
+		return ErrNothingToUndo
+	}
+	e := h.undo.Peek()
+	if err := undoCommands(e.commands); err != nil {
+		return err
+	}
+	h.redo.Push(h.undo.Pop())
+	h.sealed = true
+	return nil
+}
+
+// Redo applies the most recently undone step again and moves it back to the
+// undo stack.
+//
+// If a command fails to apply, the commands of the step that were already
+// applied are reverted, the step stays on the redo stack and the error is
+// returned.
+func (h *History) Redo() error {
+	if h.tx != nil {
+		return ErrTransactionOpen
+	}
+	if h.redo.IsEmpty() {
+		return ErrNothingToRedo
+	}
+	e := h.redo.Peek()
+	if err := doCommands(e.commands); err != nil {
+		return err
+	}
+	h.undo.Push(h.redo.Pop())
+	h.sealed = true
+	return nil
+}
+
+// CanUndo reports whether there is a step to undo.
+func (h *History) CanUndo() bool {
+	return h.tx == nil && !h.undo.IsEmpty()
+}
+
+// CanRedo reports whether there is a step to redo.
+func (h *History) CanRedo() bool {
+	return h.tx == nil && !h.redo.IsEmpty()
+}
+
+// UndoLabel returns the label of the step Undo would revert, or an empty
+// string if there is none.
+func (h *History) UndoLabel() string {
+	if h.undo.IsEmpty() {
+		return ""
+	}
+	return h.undo.Peek().label
+}
+
+// RedoLabel returns the label of the step Redo would apply, or an empty
+// string if there is none.
+func (h *History) RedoLabel() string {
+	if h.redo.IsEmpty() {
+		return ""
+	}
+	return h.redo.Peek().label
+}
+
+// UndoSize returns the number of steps on the undo stack.
+func (h *History) UndoSize() int {
+	return h.undo.Size()
+}
+
+// RedoSize returns the number of steps on the redo stack.
+func (h *History) RedoSize() int {
+	return h.redo.Size()
+}
+
+// Begin opens a transaction. All commands recorded until the matching Commit
+// form a single undo step with the given label.
+//
+// Transactions can be nested; only the outermost label is used.
+func (h *History) Begin(label string) {
+	if h.tx == nil {
+		h.tx = &entry{label: label}
+	}
+	h.txDepth++
+}
+
+// Commit closes the innermost open transaction. Closing the outermost one
+// records its commands as a single undo step; an empty transaction records
+// nothing.
+//
+// Returns ErrNoTransaction if no transaction is open.
+func (h *History) Commit() error {
+	if h.tx == nil {
+		return ErrNoTransaction
+	}
+	h.txDepth--
+	if h.txDepth > 0 {
+		return nil
+	}
+	tx := h.tx
+	h.tx = nil
+	if len(tx.commands) > 0 {
+		h.push(tx)
+	}
+	return nil
+}
+
+// Rollback reverts every command recorded in the open transaction, including
+// enclosing ones, and discards the transaction.
+//
+// Returns ErrNoTransaction if no transaction is open, or the first error
+// returned by an Undo function.
+func (h *History) Rollback() error {
+	if h.tx == nil {
+		return ErrNoTransaction
+	}
+	tx := h.tx
+	h.tx = nil
+	h.txDepth = 0
+	return undoCommands(tx.commands)
+}
+
+// Clear drops all undo and redo steps. An open transaction is discarded
+// without reverting its commands.
+func (h *History) Clear() {
+	h.undo.Clear()
+	h.redo.Clear()
+	h.tx = nil
+	h.txDepth = 0
+	h.sealed = false
+}
+
+// push adds an entry to the undo stack and enforces the maximum depth.
+func (h *History) push(e *entry) {
+	h.undo.Push(e)
+	h.sealed = false
+	if h.maxDepth > 0 && h.undo.Size() > h.maxDepth {
+		entries := h.undo.ToSlice()
+		h.undo = collections.NewStack(entries[len(entries)-h.maxDepth:]...)
+	}
+}
+
+// undoCommands reverts commands in reverse order. On failure it applies the
+// already reverted commands again so the step is left as it was.
+func undoCommands(commands []Command) error {
+	for i := len(commands) - 1; i >= 0; i-- {
+		if err := commands[i].Undo(); err != nil {
+			for j := i + 1; j < len(commands); j++ {
+				_ = commands[j].Do()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// doCommands applies commands in order. On failure it reverts the already
+// applied commands so the step is left as it was.
+func doCommands(commands []Command) error {
+	for i, cmd := range commands {
+		if err := cmd.Do(); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				_ = commands[j].Undo()
+			}
+			return err
+		}
+	}
+	return nil
+}
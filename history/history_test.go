@@ -0,0 +1,226 @@
+package history
+
+import (
+	"errors"
+	"testing"
+)
+
+type doc struct {
+	text string
+}
+
+func (d *doc) insert(s string) Command {
+	return d.insertMerged(s, "")
+}
+
+func (d *doc) insertMerged(s, mergeKey string) Command {
+	return Command{
+		Label:    "Insert " + s,
+		MergeKey: mergeKey,
+		Do: func() error {
+			d.text += s
+			return nil
+		},
+		Undo: func() error {
+			d.text = d.text[:len(d.text)-len(s)]
+			return nil
+		},
+	}
+}
+
+func TestHistory_UndoRedo(t *testing.T) {
+	d := &doc{}
+	h := New(0)
+	h.Execute(d.insert("a"))
+	h.Execute(d.insert("b"))
+
+	if !h.CanUndo() || h.CanRedo() {
+		t.Errorf("Expected undo only")
+	}
+	if h.UndoLabel() != "Insert b" {
+		t.Errorf("Expected label %q, but got %q", "Insert b", h.UndoLabel())
+	}
+
+	if err := h.Undo(); err != nil || d.text != "a" {
+		t.Errorf("Expected %q, but got %q (%v)", "a", d.text, err)
+	}
+	if h.RedoLabel() != "Insert b" {
+		t.Errorf("Expected redo label %q, but got %q", "Insert b", h.RedoLabel())
+	}
+	if err := h.Redo(); err != nil || d.text != "ab" {
+		t.Errorf("Expected %q, but got %q (%v)", "ab", d.text, err)
+	}
+
+	h.Undo()
+	h.Undo()
+	if err := h.Undo(); err != ErrNothingToUndo {
+		t.Errorf("Expected ErrNothingToUndo, but got %v", err)
+	}
+}
+
+func TestHistory_NewActionClearsRedo(t *testing.T) {
+	d := &doc{}
+	h := New(0)
+	h.Execute(d.insert("a"))
+	h.Execute(d.insert("b"))
+	h.Undo()
+	h.Execute(d.insert("c"))
+
+	if h.CanRedo() {
+		t.Errorf("Expected redo stack to be cleared")
+	}
+	if err := h.Redo(); err != ErrNothingToRedo {
+		t.Errorf("Expected ErrNothingToRedo, but got %v", err)
+	}
+	if d.text != "ac" {
+		t.Errorf("Expected %q, but got %q", "ac", d.text)
+	}
+}
+
+func TestHistory_Coalesce(t *testing.T) {
+	d := &doc{}
+	h := New(0)
+	h.Execute(d.insertMerged("h", "typing"))
+	h.Execute(d.insertMerged("i", "typing"))
+	h.Execute(d.insertMerged("!", "typing"))
+
+	if h.UndoSize() != 1 {
+		t.Errorf("Expected 1 undo step, but got %v", h.UndoSize())
+	}
+	if h.UndoLabel() != "Insert h" {
+		t.Errorf("Expected label of the first command, but got %q", h.UndoLabel())
+	}
+
+	h.Undo()
+	if d.text != "" {
+		t.Errorf("Expected empty text, but got %q", d.text)
+	}
+	h.Redo()
+	if d.text != "hi!" {
+		t.Errorf("Expected %q, but got %q", "hi!", d.text)
+	}
+
+	h.Seal()
+	h.Execute(d.insertMerged("?", "typing"))
+	if h.UndoSize() != 2 {
+		t.Errorf("Expected sealed step not to merge, but got %v steps", h.UndoSize())
+	}
+}
+
+func TestHistory_Transaction(t *testing.T) {
+	d := &doc{}
+	h := New(0)
+	h.Begin("Paste")
+	h.Execute(d.insert("x"))
+	h.Begin("Nested")
+	h.Execute(d.insert("y"))
+	h.Commit()
+
+	if err := h.Undo(); err != ErrTransactionOpen {
+		t.Errorf("Expected ErrTransactionOpen, but got %v", err)
+	}
+	h.Commit()
+
+	if h.UndoSize() != 1 || h.UndoLabel() != "Paste" {
+		t.Errorf("Expected one step labelled Paste, but got %v %q", h.UndoSize(), h.UndoLabel())
+	}
+	h.Undo()
+	if d.text != "" {
+		t.Errorf("Expected empty text, but got %q", d.text)
+	}
+
+	if err := h.Commit(); err != ErrNoTransaction {
+		t.Errorf("Expected ErrNoTransaction, but got %v", err)
+	}
+}
+
+func TestHistory_Rollback(t *testing.T) {
+	d := &doc{}
+	h := New(0)
+	h.Execute(d.insert("a"))
+	h.Begin("Batch")
+	h.Execute(d.insert("b"))
+	h.Execute(d.insert("c"))
+
+	if err := h.Rollback(); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+	if d.text != "a" {
+		t.Errorf("Expected %q, but got %q", "a", d.text)
+	}
+	if h.UndoSize() != 1 {
+		t.Errorf("Expected 1 undo step, but got %v", h.UndoSize())
+	}
+}
+
+func TestHistory_MaxDepth(t *testing.T) {
+	d := &doc{}
+	h := New(2)
+	for _, s := range []string{"a", "b", "c", "d"} {
+		h.Execute(d.insert(s))
+	}
+
+	if h.UndoSize() != 2 {
+		t.Errorf("Expected 2 undo steps, but got %v", h.UndoSize())
+	}
+	h.Undo()
+	h.Undo()
+	if d.text != "ab" {
+		t.Errorf("Expected %q, but got %q", "ab", d.text)
+	}
+	if h.CanUndo() {
+		t.Errorf("Expected oldest steps to be dropped")
+	}
+}
+
+func TestHistory_FailedUndoKeepsStep(t *testing.T) {
+	d := &doc{}
+	h := New(0)
+	fail := errors.New("boom")
+	h.Begin("Both")
+	h.Execute(Command{
+		Label: "Broken",
+		Do:    func() error { return nil },
+		Undo:  func() error { return fail },
+	})
+	h.Execute(d.insert("a"))
+	h.Commit()
+
+	if err := h.Undo(); err != fail {
+		t.Errorf("Expected %v, but got %v", fail, err)
+	}
+	if d.text != "a" {
+		t.Errorf("Expected partial undo to be reverted, but got %q", d.text)
+	}
+	if h.UndoSize() != 1 || h.CanRedo() {
+		t.Errorf("Expected step to stay on the undo stack")
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	s := NewSnapshots("", 0)
+	s.Save("Type a", "a")
+	s.SaveMerged("Drag", "drag", "ab")
+	s.SaveMerged("Drag", "drag", "abc")
+
+	if s.Current() != "abc" {
+		t.Errorf("Expected %q, but got %q", "abc", s.Current())
+	}
+
+	state, err := s.Undo()
+	if err != nil || state != "a" {
+		t.Errorf("Expected %q, but got %q (%v)", "a", state, err)
+	}
+	state, _ = s.Undo()
+	if state != "" {
+		t.Errorf("Expected initial state, but got %q", state)
+	}
+	if _, err := s.Undo(); err != ErrNothingToUndo {
+		t.Errorf("Expected ErrNothingToUndo, but got %v", err)
+	}
+
+	state, _ = s.Redo()
+	if state != "a" || s.RedoLabel() != "Drag" {
+		t.Errorf("Expected %q with redo label Drag, but got %q and %q", "a", state, s.RedoLabel())
+	}
+}
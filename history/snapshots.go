@@ -0,0 +1,109 @@
+package history
+
+type Snapshots[S any] struct {
+	history *History
+	current S
+}
+
+// NewSnapshots creates a snapshot-based history that starts at the initial
+// state.
+//
+// Instead of reversible commands, every change is recorded as a full copy of
+// the new state. maxDepth has the same meaning as in New.
+// Returns a pointer to the new Snapshots.
+func NewSnapshots[S any](initial S, maxDepth int) *Snapshots[S] {
+	return &Snapshots[S]{history: New(maxDepth), current: initial}
+}
+
+// Save records state as the new current state and clears the redo stack.
+//
+// The caller must not modify state afterwards; save a copy instead.
+func (s *Snapshots[S]) Save(label string, state S) {
+	s.SaveMerged(label, "", state)
+}
+
+// SaveMerged records state like Save, but coalesces it with the previous
+// snapshot if both were saved with the same non-empty mergeKey.
+func (s *Snapshots[S]) SaveMerged(label, mergeKey string, state S) {
+	prev := s.current
+	s.current = state
+	s.history.Record(Command{
+		Label:    label,
+		MergeKey: mergeKey,
+		Do: func() error {
+			s.current = state
+			return nil
+		},
+		Undo: func() error {
+			s.current = prev
+			return nil
+		},
+	})
+}
+
+// Current returns the current state.
+func (s *Snapshots[S]) Current() S {
+	return s.current
+}
+
+// Undo restores the state before the most recent undo step.
+//
+// Returns the restored state, or ErrNothingToUndo or ErrTransactionOpen.
+func (s *Snapshots[S]) Undo() (S, error) {
+	err := s.history.Undo()
+	return s.current, err
+}
+
+// Redo restores the state after the most recently undone step.
+//
+// Returns the restored state, or ErrNothingToRedo or ErrTransactionOpen.
+func (s *Snapshots[S]) Redo() (S, error) {
+	err := s.history.Redo()
+	return s.current, err
+}
+
+// CanUndo reports whether there is a step to undo.
+func (s *Snapshots[S]) CanUndo() bool {
+	return s.history.CanUndo()
+}
+
+// CanRedo reports whether there is a step to redo.
+func (s *Snapshots[S]) CanRedo() bool {
+	return s.history.CanRedo()
+}
+
+// UndoLabel returns the label of the step Undo would revert.
+func (s *Snapshots[S]) UndoLabel() string {
+	return s.history.UndoLabel()
+}
+
+// RedoLabel returns the label of the step Redo would apply.
+func (s *Snapshots[S]) RedoLabel() string {
+	return s.history.RedoLabel()
+}
+
+// Seal stops the next snapshot from being coalesced with the last one.
+func (s *Snapshots[S]) Seal() {
+	s.history.Seal()
+}
+
+// Begin opens a transaction; see History.Begin.
+func (s *Snapshots[S]) Begin(label string) {
+	s.history.Begin(label)
+}
+
+// Commit closes the innermost open transaction; see History.Commit.
+func (s *Snapshots[S]) Commit() error {
+	return s.history.Commit()
+}
+
+// Rollback restores the state from before the open transaction and discards
+// it; see History.Rollback.
+func (s *Snapshots[S]) Rollback() error {
+	return s.history.Rollback()
+}
+
+// Clear drops all undo and redo steps and keeps the current state.
+func (s *Snapshots[S]) Clear() {
+	s.history.Clear()
+}
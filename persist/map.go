@@ -0,0 +1,401 @@
+package persist
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+// ErrClosed is returned by the methods of a closed Map that change it.
+var ErrClosed = errors.New("persist: map is closed")
+
+type SyncPolicy int
+
+const (
+	// SyncAlways fsyncs the log after every write, so a write that returned
+	// survives a crash of the machine.
+	SyncAlways SyncPolicy = iota
+	// SyncInterval fsyncs the log in the background every Options.Interval
+	// if it changed, so a crash loses at most the last interval of writes.
+	SyncInterval
+	// SyncNever leaves flushing to the operating system. Writes survive a
+	// crash of the process but not of the machine.
+	SyncNever
+)
+
+type Options struct {
+	Sync SyncPolicy
+	// Interval is the period of SyncInterval. The default is one second.
+	Interval time.Duration
+}
+
+const (
+	opSet    = "set"
+	opDelete = "delete"
+	opClear  = "clear"
+)
+
+type record[K comparable, V any] struct {
+	Op    string `json:"op"`
+	Key   K      `json:"key,omitempty"`
+	Value V      `json:"value,omitempty"`
+}
+
+type Map[K comparable, V any] struct {
+	mu      sync.Mutex
+	path    string
+	options Options
+	file    *os.File
+	size    int64
+	dirty   bool
+	closed  bool
+	m       *collections.OrderedMap[K]
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// Open opens the map stored in the log at path, creating the file if it
+// does not exist. Every change is appended to the log as a record of
+// [length uint32][payload crc32 uint32][header crc32 uint32][JSON payload],
+// and the log is replayed on open. A torn last record, as left by a crash in
+// the middle of a write, is cut off. Keys and values must round-trip through
+// encoding/json.
+//
+// Returns a pointer to the Map, or the error that prevented reading the log.
+// A record in the middle of the log that fails a checksum is ErrCorrupt,
+// and a record that is intact but does not decode into K and V is an error;
+// in both cases the log is left as it is.
+func Open[K comparable, V any](path string, options Options) (*Map[K, V], error) {
+	if options.Interval <= 0 {
+		options.Interval = time.Second
+	}
+	// A leftover temporary file is a compaction that never got renamed; the
+	// log it was built from is still complete.
+	if err := os.Remove(path + ".tmp"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	pm := &Map[K, V]{path: path, options: options, file: file, m: collections.NewOrderedMap[K]()}
+	if err := pm.replay(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if options.Sync == SyncInterval {
+		pm.stop, pm.stopped = make(chan struct{}), make(chan struct{})
+		go pm.syncLoop()
+	}
+	return pm, nil
+}
+
+// Set adds or updates a key-value pair and appends it to the log.
+//
+// Returns the error of writing the log, in which case the map is unchanged.
+func (pm *Map[K, V]) Set(key K, value V) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if err := pm.append(record[K, V]{Op: opSet, Key: key, Value: value}); err != nil {
+		return err
+	}
+	pm.m.Set(key, value)
+	return nil
+}
+
+// Delete deletes key and appends the deletion to the log. Deleting a
+// missing key writes nothing.
+//
+// Returns the error of writing the log, in which case the map is unchanged.
+func (pm *Map[K, V]) Delete(key K) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if _, ok := pm.m.Get(key); !ok {
+		return nil
+	}
+	if err := pm.append(record[K, V]{Op: opDelete, Key: key}); err != nil {
+		return err
+	}
+	pm.m.Delete(key)
+	return nil
+}
+
+// Clear removes all pairs and appends that to the log.
+//
+// Returns the error of writing the log, in which case the map is unchanged.
+func (pm *Map[K, V]) Clear() error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if err := pm.append(record[K, V]{Op: opClear}); err != nil {
+		return err
+	}
+	pm.m.Clear()
+	return nil
+}
+
+// Get returns the value for key and whether it exists.
+func (pm *Map[K, V]) Get(key K) (V, bool) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	v, ok := pm.m.Get(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return v.(V), true
+}
+
+// Keys returns the keys in insertion order.
+func (pm *Map[K, V]) Keys() []K {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	return pm.m.Keys()
+}
+
+// Values returns the values in insertion order of their keys.
+func (pm *Map[K, V]) Values() []V {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	values := make([]V, 0, pm.m.Len())
+	for _, v := range pm.m.Values() {
+		values = append(values, v.(V))
+	}
+	return values
+}
+
+// Len returns the number of pairs.
+func (pm *Map[K, V]) Len() int {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	return pm.m.Len()
+}
+
+// IsEmpty checks if the map has no pairs.
+func (pm *Map[K, V]) IsEmpty() bool {
+	return pm.Len() == 0
+}
+
+// LogSize returns the size of the log in bytes, which helps to decide when
+// to call Compact.
+func (pm *Map[K, V]) LogSize() int64 {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	return pm.size
+}
+
+// Compact rewrites the log with one record per pair. The new log is written
+// to a temporary file, synced and renamed over the old one, so a crash at
+// any point leaves either the old or the new log.
+//
+// Returns the first error, in which case the old log stays in use.
+func (pm *Map[K, V]) Compact() error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if pm.closed {
+		return ErrClosed
+	}
+
+	var buf []byte
+	for _, kv := range pm.m.ToKeyValueArray() {
+		payload, err := json.Marshal(record[K, V]{Op: opSet, Key: kv.Key, Value: kv.Value.(V)})
+		if err != nil {
+			return err
+		}
+		buf = appendRecord(buf, payload)
+	}
+	// The new log is written through the handle that stays in use, so there
+	// is nothing left to open once the rename has replaced the old log.
+	tmp := pm.path + ".tmp"
+	file, err := createSynced(tmp, buf)
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, pm.path); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return err
+	}
+	syncDir(filepath.Dir(pm.path))
+	pm.file.Close()
+	pm.file, pm.size, pm.dirty = file, int64(len(buf)), false
+	return nil
+}
+
+// Sync fsyncs the log.
+func (pm *Map[K, V]) Sync() error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if pm.closed {
+		return ErrClosed
+	}
+	return pm.sync()
+}
+
+// Close syncs and closes the log. Reads keep working on the last state.
+func (pm *Map[K, V]) Close() error {
+	pm.mu.Lock()
+	if pm.closed {
+		pm.mu.Unlock()
+		return nil
+	}
+	pm.closed = true
+	pm.mu.Unlock()
+
+	if pm.stop != nil {
+		close(pm.stop)
+		<-pm.stopped
+	}
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	err := pm.file.Sync()
+	if cerr := pm.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// replay applies every record of the log and cuts off a torn last record.
+func (pm *Map[K, V]) replay() error {
+	info, err := pm.file.Stat()
+	if err != nil {
+		return err
+	}
+	r := bufio.NewReader(pm.file)
+	for {
+		payload, err := readRecord(r, info.Size()-pm.size)
+		if err == io.EOF {
+			break
+		}
+		if err == ErrCorrupt {
+			return fmt.Errorf("%w at offset %d", ErrCorrupt, pm.size)
+		}
+		if err != nil && err != errTornRecord {
+			return err
+		}
+		if err == errTornRecord {
+			if err := pm.file.Truncate(pm.size); err != nil {
+				return err
+			}
+			break
+		}
+		// An intact record that does not decode was written with other key
+		// or value types. It is not a torn write, so the log is left alone.
+		var rec record[K, V]
+		if err := json.Unmarshal(payload, &rec); err != nil {
+			return fmt.Errorf("persist: decode record at offset %d: %w", pm.size, err)
+		}
+		pm.apply(rec)
+		pm.size += int64(headerSize + len(payload))
+	}
+	_, err = pm.file.Seek(pm.size, io.SeekStart)
+	return err
+}
+
+func (pm *Map[K, V]) apply(rec record[K, V]) {
+	switch rec.Op {
+	case opSet:
+		pm.m.Set(rec.Key, rec.Value)
+	case opDelete:
+		pm.m.Delete(rec.Key)
+	case opClear:
+		pm.m.Clear()
+	}
+}
+
+// append writes rec to the log with a single write. A failed write, or under
+// SyncAlways a failed fsync, is cut off again so that the record does not
+// come back on replay and cannot hide later records from it.
+func (pm *Map[K, V]) append(rec record[K, V]) error {
+	if pm.closed {
+		return ErrClosed
+	}
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	buf := appendRecord(nil, payload)
+	if _, err := pm.file.Write(buf); err != nil {
+		pm.rewind()
+		return err
+	}
+	pm.dirty = true
+	if pm.options.Sync == SyncAlways {
+		if err := pm.sync(); err != nil {
+			pm.rewind()
+			return err
+		}
+	}
+	pm.size += int64(len(buf))
+	return nil
+}
+
+// rewind cuts the log back to the end of the last record that was
+// accepted.
+func (pm *Map[K, V]) rewind() {
+	pm.file.Truncate(pm.size)
+	pm.file.Seek(pm.size, io.SeekStart)
+}
+
+func (pm *Map[K, V]) sync() error {
+	if !pm.dirty {
+		return nil
+	}
+	if err := pm.file.Sync(); err != nil {
+		return err
+	}
+	pm.dirty = false
+	return nil
+}
+
+func (pm *Map[K, V]) syncLoop() {
+	defer close(pm.stopped)
+	ticker := time.NewTicker(pm.options.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			pm.mu.Lock()
+			pm.sync()
+			pm.mu.Unlock()
+		case <-pm.stop:
+			return
+		}
+	}
+}
+
+// createSynced writes data to a new file at path and fsyncs it.
+//
+// Returns the file, open for reading and writing at its end.
+func createSynced(path string, data []byte) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+// syncDir fsyncs a directory so that a rename in it is durable. Errors are
+// ignored because not every platform supports it.
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
@@ -0,0 +1,275 @@
+package persist
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type checkpoint struct {
+	Offset int64  `json:"offset"`
+	Owner  string `json:"owner"`
+}
+
+func TestMap_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.log")
+	m, err := Open[string, checkpoint](path, Options{})
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	m.Set("a", checkpoint{1, "x"})
+	m.Set("b", checkpoint{2, "y"})
+	m.Set("c", checkpoint{3, "z"})
+	m.Set("a", checkpoint{4, "x"})
+	m.Delete("b")
+	m.Delete("missing")
+	if err := m.Close(); err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	if err := m.Set("d", checkpoint{}); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, but got %v", err)
+	}
+
+	m, err = Open[string, checkpoint](path, Options{})
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	defer m.Close()
+	if result := m.Keys(); !reflect.DeepEqual(result, []string{"a", "c"}) {
+		t.Errorf("Expected [a c], but got %v", result)
+	}
+	if v, ok := m.Get("a"); !ok || v != (checkpoint{4, "x"}) {
+		t.Errorf("Expected {4 x}, but got %v", v)
+	}
+	if result := m.Values(); !reflect.DeepEqual(result, []checkpoint{{4, "x"}, {3, "z"}}) {
+		t.Errorf("Unexpected values %v", result)
+	}
+
+	m.Clear()
+	m.Set("e", checkpoint{5, "w"})
+	m.Close()
+	m, _ = Open[string, checkpoint](path, Options{})
+	if result := m.Keys(); !reflect.DeepEqual(result, []string{"e"}) {
+		t.Errorf("Expected [e], but got %v", result)
+	}
+	m.Close()
+}
+
+// TestMap_CrashRecovery cuts the log at every byte, as a crash in the middle
+// of a write would, and checks that the map recovers every record written
+// before the cut and accepts new writes.
+func TestMap_CrashRecovery(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.log")
+	m, err := Open[string, int](path, Options{Sync: SyncNever})
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	keys := []string{"a", "b", "c", "a", "d"}
+	ends := []int64{0}
+	var states [][]string
+	states = append(states, []string{})
+	for i, k := range keys {
+		m.Set(k, i)
+		ends = append(ends, m.LogSize())
+		states = append(states, m.Keys())
+	}
+	// The process crashes here: the map is never closed.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+
+	for cut := int64(0); cut <= int64(len(data)); cut++ {
+		crashed := filepath.Join(dir, "crashed.log")
+		if err := os.WriteFile(crashed, data[:cut], 0o644); err != nil {
+			t.Fatalf("Expected nil, but got %v", err)
+		}
+		complete := 0
+		for complete+1 < len(ends) && ends[complete+1] <= cut {
+			complete++
+		}
+
+		r, err := Open[string, int](crashed, Options{Sync: SyncNever})
+		if err != nil {
+			t.Fatalf("Cut %v: expected nil, but got %v", cut, err)
+		}
+		if result := r.Keys(); !reflect.DeepEqual(result, states[complete]) {
+			t.Fatalf("Cut %v: expected %v, but got %v", cut, states[complete], result)
+		}
+		if r.LogSize() != ends[complete] {
+			t.Fatalf("Cut %v: expected the torn tail to be truncated to %v, but the log has %v bytes", cut, ends[complete], r.LogSize())
+		}
+		r.Set("new", 99)
+		r.Close()
+
+		r, _ = Open[string, int](crashed, Options{})
+		if v, ok := r.Get("new"); !ok || v != 99 || r.Len() != len(states[complete])+1 {
+			t.Fatalf("Cut %v: expected the write after recovery to survive, but got %v", cut, r.Keys())
+		}
+		r.Close()
+	}
+
+	// A damaged length that points past the end of the log is not a torn
+	// tail: the records after it are intact, so Open fails and keeps them.
+	crashed := filepath.Join(dir, "crashed.log")
+	corrupt := append([]byte(nil), data...)
+	binary.LittleEndian.PutUint32(corrupt[0:4], 1<<20)
+	os.WriteFile(crashed, corrupt, 0o644)
+	if _, err := Open[string, int](crashed, Options{}); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Expected ErrCorrupt, but got %v", err)
+	}
+	if after, _ := os.ReadFile(crashed); !reflect.DeepEqual(after, corrupt) {
+		t.Errorf("Expected the log to be left alone, but it went from %v to %v bytes", len(corrupt), len(after))
+	}
+
+	// A crash can also grow the file without its data reaching the disk,
+	// which leaves zeros after the last record.
+	os.WriteFile(crashed, append(append([]byte(nil), data...), make([]byte, 100)...), 0o644)
+	r, err := Open[string, int](crashed, Options{})
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	defer r.Close()
+	if result := r.Keys(); !reflect.DeepEqual(result, states[len(keys)]) || r.LogSize() != int64(len(data)) {
+		t.Errorf("Expected %v in %v bytes, but got %v in %v bytes", states[len(keys)], len(data), result, r.LogSize())
+	}
+}
+
+func TestMap_CorruptRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.log")
+	m, _ := Open[string, int](path, Options{})
+	m.Set("a", 1)
+	first := m.LogSize()
+	m.Set("b", 2)
+	second := m.LogSize()
+	m.Set("c", 3)
+	m.Close()
+	data, _ := os.ReadFile(path)
+
+	// A bad checksum in the middle of the log is not a torn write: Open fails
+	// and the intact records after it stay in the file.
+	corrupt := append([]byte(nil), data...)
+	corrupt[first+headerSize+2] ^= 0xff
+	os.WriteFile(path, corrupt, 0o644)
+	if _, err := Open[string, int](path, Options{}); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Expected ErrCorrupt, but got %v", err)
+	}
+	if after, _ := os.ReadFile(path); !reflect.DeepEqual(after, corrupt) {
+		t.Errorf("Expected the log to be left alone")
+	}
+
+	// A bad checksum in the last record is a torn tail and is cut off.
+	torn := append([]byte(nil), data...)
+	torn[second+headerSize+2] ^= 0xff
+	os.WriteFile(path, torn, 0o644)
+	m, err := Open[string, int](path, Options{})
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	defer m.Close()
+	if result := m.Keys(); !reflect.DeepEqual(result, []string{"a", "b"}) {
+		t.Errorf("Expected [a b], but got %v", result)
+	}
+	if m.LogSize() != second {
+		t.Errorf("Expected the torn record to be cut off at %v, but the log has %v bytes", second, m.LogSize())
+	}
+}
+
+func TestMap_Compact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.log")
+	m, _ := Open[string, int](path, Options{})
+	for i := 0; i < 100; i++ {
+		m.Set("counter", i)
+		m.Set("other", -i)
+	}
+	m.Delete("other")
+	m.Set("last", 1)
+	before := m.LogSize()
+
+	if err := m.Compact(); err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	if m.LogSize()*20 > before {
+		t.Errorf("Expected compaction to shrink the log from %v bytes, but got %v", before, m.LogSize())
+	}
+	if info, _ := os.Stat(path); info.Size() != m.LogSize() {
+		t.Errorf("Expected the file to hold %v bytes, but got %v", m.LogSize(), info.Size())
+	}
+	m.Set("after", 2)
+	m.Close()
+
+	// A compaction that crashed before the rename leaves a temporary file
+	// behind, which must not affect the log.
+	os.WriteFile(path+".tmp", []byte("partial"), 0o644)
+	m, err := Open[string, int](path, Options{})
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	defer m.Close()
+	if result := m.Keys(); !reflect.DeepEqual(result, []string{"counter", "last", "after"}) {
+		t.Errorf("Expected [counter last after], but got %v", result)
+	}
+	if v, _ := m.Get("counter"); v != 99 {
+		t.Errorf("Expected 99, but got %v", v)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("Expected the temporary file to be removed")
+	}
+}
+
+func TestMap_SyncInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.log")
+	m, err := Open[int, string](path, Options{Sync: SyncInterval, Interval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		m.Set(i, "v")
+		time.Sleep(time.Millisecond)
+	}
+	if err := m.Sync(); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+	if err := m.Close(); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+	if err := m.Sync(); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, but got %v", err)
+	}
+
+	m, _ = Open[int, string](path, Options{})
+	defer m.Close()
+	if m.Len() != 20 {
+		t.Errorf("Expected 20 pairs, but got %v", m.Len())
+	}
+}
+
+func TestMap_MismatchedType(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.log")
+	m, _ := Open[string, string](path, Options{})
+	m.Set("a", "x")
+	m.Set("b", "y")
+	m.Close()
+	before, _ := os.ReadFile(path)
+
+	if _, err := Open[string, int](path, Options{}); err == nil {
+		t.Errorf("Expected a decode error for the wrong value type")
+	}
+	if after, _ := os.ReadFile(path); !reflect.DeepEqual(after, before) {
+		t.Errorf("Expected the log to be left alone, but it went from %v to %v bytes", len(before), len(after))
+	}
+
+	m, err := Open[string, string](path, Options{})
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	defer m.Close()
+	if m.Len() != 2 {
+		t.Errorf("Expected 2 pairs, but got %v", m.Len())
+	}
+}
@@ -0,0 +1,107 @@
+package persist
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+)
+
+// headerSize is the size of the record header: the payload length, the
+// CRC-32 (IEEE) of the payload and the CRC-32 of those first eight bytes, all
+// little-endian uint32. The header checksum lets a damaged length be told
+// apart from a record that was cut short.
+const headerSize = 12
+
+var (
+	// ErrCorrupt is returned by Open when a record in the middle of the log
+	// fails a checksum. Only the last record can be torn by a crash, so the
+	// log is left alone rather than losing the intact records after it.
+	ErrCorrupt = errors.New("persist: corrupt record in log")
+
+	// errTornRecord reports a last record that was cut short or fails a
+	// checksum, which is what a crash in the middle of a write leaves behind.
+	errTornRecord = errors.New("persist: torn record")
+)
+
+// appendRecord appends the framed payload to buf.
+func appendRecord(buf, payload []byte) []byte {
+	var header [headerSize]byte
+	binary.LittleEndian.PutUint32(header[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(header[4:8], crc32.ChecksumIEEE(payload))
+	binary.LittleEndian.PutUint32(header[8:12], crc32.ChecksumIEEE(header[0:8]))
+	buf = append(buf, header[:]...)
+	return append(buf, payload...)
+}
+
+// readRecord reads the next record from r, which has remaining bytes left.
+//
+// Returns io.EOF at a clean end of the log, errTornRecord for a record that
+// is incomplete or is the last thing in the log and fails a checksum, and
+// ErrCorrupt for any other record that fails a checksum.
+func readRecord(r io.Reader, remaining int64) ([]byte, error) {
+	if remaining == 0 {
+		return nil, io.EOF
+	}
+	var header [headerSize]byte
+	if remaining < headerSize {
+		return nil, errTornRecord
+	}
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, torn(err)
+	}
+	if crc32.ChecksumIEEE(header[0:8]) != binary.LittleEndian.Uint32(header[8:12]) {
+		// The length cannot be trusted, so the record is only known to be the
+		// last one if nothing but zeros follows it.
+		zero, err := zeroTail(r, remaining-headerSize)
+		if err != nil {
+			return nil, torn(err)
+		}
+		if zero {
+			return nil, errTornRecord
+		}
+		return nil, ErrCorrupt
+	}
+	n := binary.LittleEndian.Uint32(header[0:4])
+	if int64(n) > remaining-headerSize {
+		return nil, errTornRecord
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, torn(err)
+	}
+	if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(header[4:8]) {
+		if int64(n) == remaining-headerSize {
+			return nil, errTornRecord
+		}
+		return nil, ErrCorrupt
+	}
+	return payload, nil
+}
+
+// zeroTail reports whether the next n bytes of r are all zero, which is what a
+// crash leaves when the file grew but the data never reached the disk.
+func zeroTail(r io.Reader, n int64) (bool, error) {
+	buf := make([]byte, 4096)
+	for n > 0 {
+		k, err := io.ReadFull(r, buf[:min(n, int64(len(buf)))])
+		for _, b := range buf[:k] {
+			if b != 0 {
+				return false, nil
+			}
+		}
+		if err != nil {
+			return false, err
+		}
+		n -= int64(k)
+	}
+	return true, nil
+}
+
+// torn reports a log that ends earlier than its size said as a torn record.
+func torn(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errTornRecord
+	}
+	return err
+}
@@ -0,0 +1,204 @@
+package reconcile
+
+import (
+	"sort"
+
+	"github.com/kxrxh/goloom/collections"
+	"github.com/kxrxh/goloom/hashring"
+)
+
+// Range is the half-open range [Lower, Upper) of strings. An empty Upper
+// means the range has no upper bound, so the zero Range covers everything.
+type Range struct {
+	Lower string `json:"lower"`
+	Upper string `json:"upper,omitempty"`
+}
+
+// Contains checks if s lies in the range.
+func (r Range) Contains(s string) bool {
+	return s >= r.Lower && (r.Upper == "" || s < r.Upper)
+}
+
+// Summary is the fingerprint of the elements of a digest in a range: how many
+// there are and the sum of their hashes. Equal summaries mean equal contents
+// with high probability.
+type Summary struct {
+	Range
+	Count int    `json:"count"`
+	Hash  uint64 `json:"hash"`
+}
+
+type Digest struct {
+	elements []string
+	// prefix[i] is the sum of the hashes of elements[:i].
+	prefix []uint64
+}
+
+// New builds a digest of the given elements. The elements are sorted and
+// duplicates are dropped, so the summary of any range can be computed in
+// O(log n).
+//
+// Returns a pointer to the new Digest.
+func New(elems []string) *Digest {
+	sorted := append([]string(nil), elems...)
+	sort.Strings(sorted)
+	d := &Digest{elements: sorted[:0], prefix: []uint64{0}}
+	for i, e := range sorted {
+		if i > 0 && e == sorted[i-1] {
+			continue
+		}
+		d.elements = append(d.elements, e)
+		d.prefix = append(d.prefix, d.prefix[len(d.prefix)-1]+hash(e))
+	}
+	return d
+}
+
+// FromSet builds a digest of the elements of s.
+func FromSet(s collections.Set[string]) *Digest {
+	return New(s.ToSlice())
+}
+
+// FromOrderedSet builds a digest of the elements of s. The insertion order of
+// s does not matter.
+func FromOrderedSet(s collections.OrderedSet[string]) *Digest {
+	return New(s.ToSlice())
+}
+
+// Len returns the number of elements in the digest.
+func (d *Digest) Len() int {
+	return len(d.elements)
+}
+
+// Root returns the summary of all elements, which is enough to tell whether
+// two digests are equal.
+func (d *Digest) Root() Summary {
+	return d.Summarize(Range{})
+}
+
+// Summarize returns the summary of the elements in r.
+func (d *Digest) Summarize(r Range) Summary {
+	i, j := d.bounds(r)
+	return Summary{Range: r, Count: j - i, Hash: d.prefix[j] - d.prefix[i]}
+}
+
+// Elements returns the elements in r in sorted order.
+func (d *Digest) Elements(r Range) []string {
+	i, j := d.bounds(r)
+	return append([]string(nil), d.elements[i:j]...)
+}
+
+// Split divides r into up to parts consecutive ranges holding about the same
+// number of elements of the digest. Every range holds fewer elements than r
+// as long as r holds at least two.
+func (d *Digest) Split(r Range, parts int) []Range {
+	i, j := d.bounds(r)
+	n := j - i
+	parts = max(1, min(parts, n))
+	ranges := make([]Range, 0, parts)
+	lower := r.Lower
+	for p := 1; p < parts; p++ {
+		upper := d.elements[i+n*p/parts]
+		ranges = append(ranges, Range{lower, upper})
+		lower = upper
+	}
+	return append(ranges, Range{lower, r.Upper})
+}
+
+func (d *Digest) bounds(r Range) (int, int) {
+	i := sort.SearchStrings(d.elements, r.Lower)
+	j := len(d.elements)
+	if r.Upper != "" {
+		j = max(i, sort.SearchStrings(d.elements, r.Upper))
+	}
+	return i, j
+}
+
+// Diff holds the differences between a local and a remote set.
+type Diff struct {
+	// Missing holds the elements only the remote set has.
+	Missing []string
+	// Extra holds the elements only the local set has.
+	Extra []string
+}
+
+// IsEmpty checks if the sets are equal.
+func (d Diff) IsEmpty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0
+}
+
+type Config struct {
+	// ItemThreshold is the number of elements at or below which a range is
+	// sent as a list of elements instead of being split further. The
+	// default is 16.
+	ItemThreshold int
+	// Branching is the number of ranges a differing range is split into. The
+	// default is 16.
+	Branching int
+}
+
+func (c Config) withDefaults() Config {
+	if c.ItemThreshold <= 0 {
+		c.ItemThreshold = 16
+	}
+	if c.Branching < 2 {
+		c.Branching = 16
+	}
+	return c
+}
+
+// Compare finds the differences between two digests held in the same
+// process, descending only into the ranges whose summaries differ. Remote
+// plays the part of the peer in the protocol used by Reconcile.
+//
+// Returns the sorted differences from the point of view of local.
+func Compare(local, remote *Digest, config Config) Diff {
+	config = config.withDefaults()
+	var diff Diff
+	var walk func(r Range)
+	walk = func(r Range) {
+		summary := remote.Summarize(r)
+		if local.Summarize(r) == summary {
+			return
+		}
+		if summary.Count <= config.ItemThreshold {
+			diff.add(local.Elements(r), summary.Range, remote.Elements(r))
+			return
+		}
+		for _, child := range remote.Split(r, config.Branching) {
+			walk(child)
+		}
+	}
+	walk(Range{})
+	diff.sort()
+	return diff
+}
+
+// add records the differences between the local elements of r and the
+// remote ones. Both slices must be sorted.
+func (d *Diff) add(local []string, r Range, remote []string) {
+	i, j := 0, 0
+	for i < len(local) || j < len(remote) {
+		switch {
+		case j == len(remote) || (i < len(local) && local[i] < remote[j]):
+			d.Extra = append(d.Extra, local[i])
+			i++
+		case i == len(local) || remote[j] < local[i]:
+			if r.Contains(remote[j]) {
+				d.Missing = append(d.Missing, remote[j])
+			}
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+}
+
+func (d *Diff) sort() {
+	sort.Strings(d.Missing)
+	sort.Strings(d.Extra)
+}
+
+func hash(s string) uint64 {
+	return hashring.DefaultHash([]byte(s))
+}
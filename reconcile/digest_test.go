@@ -0,0 +1,94 @@
+package reconcile
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+// replicas returns two random sets of object IDs that share most elements,
+// and the brute-force differences between them.
+func replicas(rng *rand.Rand, shared, changes int) ([]string, []string, Diff) {
+	var local, remote []string
+	for i := 0; i < shared; i++ {
+		id := "obj-" + strconv.Itoa(rng.Intn(1<<30))
+		local = append(local, id)
+		remote = append(remote, id)
+	}
+	var diff Diff
+	for i := 0; i < changes; i++ {
+		id := "new-" + strconv.Itoa(rng.Intn(1<<30))
+		if rng.Intn(2) == 0 {
+			local = append(local, id)
+			diff.Extra = append(diff.Extra, id)
+		} else {
+			remote = append(remote, id)
+			diff.Missing = append(diff.Missing, id)
+		}
+	}
+	diff.sort()
+	return local, remote, diff
+}
+
+func TestDigest_Summarize(t *testing.T) {
+	d := New([]string{"c", "a", "b", "a", "e"})
+	if d.Len() != 4 {
+		t.Errorf("Expected 4 distinct elements, but got %v", d.Len())
+	}
+	if result := d.Elements(Range{"b", "e"}); !reflect.DeepEqual(result, []string{"b", "c"}) {
+		t.Errorf("Expected [b c], but got %v", result)
+	}
+	if s := d.Summarize(Range{"b", "d"}); s.Count != 2 || s.Hash != hash("b")+hash("c") {
+		t.Errorf("Unexpected summary %v", s)
+	}
+	if s := d.Summarize(Range{"d", "b"}); s.Count != 0 || s.Hash != 0 {
+		t.Errorf("Expected an empty summary for an empty range, but got %v", s)
+	}
+
+	other := FromOrderedSet(collections.NewOrderedSet("e", "c", "b", "a"))
+	if d.Root() != other.Root() {
+		t.Errorf("Expected equal contents to have equal roots")
+	}
+	if FromSet(collections.NewSet("a", "b")).Root() == d.Root() {
+		t.Errorf("Expected different contents to have different roots")
+	}
+}
+
+func TestDigest_Split(t *testing.T) {
+	d := New([]string{"a", "b", "c", "d", "e", "f", "g"})
+	ranges := d.Split(Range{"b", ""}, 3)
+	expected := []Range{{"b", "d"}, {"d", "f"}, {"f", ""}}
+	if !reflect.DeepEqual(ranges, expected) {
+		t.Errorf("Expected %v, but got %v", expected, ranges)
+	}
+	if result := d.Split(Range{"x", ""}, 4); !reflect.DeepEqual(result, []Range{{"x", ""}}) {
+		t.Errorf("Expected an empty range to stay whole, but got %v", result)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, changes := range []int{0, 1, 5, 200} {
+		local, remote, expected := replicas(rng, 3000, changes)
+		diff := Compare(New(local), New(remote), Config{})
+		if !reflect.DeepEqual(diff.Missing, expected.Missing) || !reflect.DeepEqual(diff.Extra, expected.Extra) {
+			t.Errorf("Expected %v, but got %v", expected, diff)
+		}
+		if diff.IsEmpty() != (changes == 0) {
+			t.Errorf("Unexpected IsEmpty for %v changes", changes)
+		}
+	}
+
+	// Disjoint and empty sets.
+	diff := Compare(New(nil), New([]string{"b", "a"}), Config{ItemThreshold: 1, Branching: 2})
+	if !reflect.DeepEqual(diff.Missing, []string{"a", "b"}) || diff.Extra != nil {
+		t.Errorf("Expected [a b] to be missing, but got %v", diff)
+	}
+	if !sort.StringsAreSorted(diff.Missing) {
+		t.Errorf("Expected sorted output")
+	}
+}
@@ -0,0 +1,134 @@
+package reconcile
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// ErrProtocol is returned when the peer sends a message that does not answer
+// the previous one.
+var ErrProtocol = errors.New("reconcile: unexpected message from peer")
+
+const (
+	replyMatch = "match"
+	replyItems = "items"
+	replySplit = "split"
+)
+
+// request carries the summaries of the ranges the initiator still has to
+// check. A request without ranges ends the session.
+type request struct {
+	Ranges []Summary `json:"ranges"`
+}
+
+type reply struct {
+	Kind     string    `json:"kind"`
+	Range    Range     `json:"range"`
+	Items    []string  `json:"items,omitempty"`
+	Children []Summary `json:"children,omitempty"`
+}
+
+type response struct {
+	Replies []reply `json:"replies"`
+}
+
+// Reconcile finds the differences between local and the digest served by
+// Serve on the other end of r and w. Every round sends the summaries of the
+// ranges that still differ, and the peer answers each one with a match, its
+// elements in the range if there are few, or the summaries of smaller
+// ranges. The traffic grows with the number of differences rather than the
+// size of the sets.
+//
+// Messages are JSON values. Reconcile ends the session before it returns.
+// Returns the sorted differences from the point of view of local.
+func Reconcile(local *Digest, r io.Reader, w io.Writer) (Diff, error) {
+	enc, dec := json.NewEncoder(w), json.NewDecoder(r)
+	var diff Diff
+	pending := []Range{{}}
+	for len(pending) > 0 {
+		req := request{Ranges: make([]Summary, len(pending))}
+		for i, rng := range pending {
+			req.Ranges[i] = local.Summarize(rng)
+		}
+		if err := enc.Encode(req); err != nil {
+			return Diff{}, err
+		}
+		var resp response
+		if err := dec.Decode(&resp); err != nil {
+			return Diff{}, err
+		}
+		if len(resp.Replies) != len(pending) {
+			return Diff{}, ErrProtocol
+		}
+
+		var next []Range
+		for i, rep := range resp.Replies {
+			if rep.Range != pending[i] {
+				return Diff{}, ErrProtocol
+			}
+			switch rep.Kind {
+			case replyMatch:
+			case replyItems:
+				diff.add(local.Elements(rep.Range), rep.Range, New(rep.Items).elements)
+			case replySplit:
+				for _, child := range rep.Children {
+					if local.Summarize(child.Range) != child {
+						next = append(next, child.Range)
+					}
+				}
+			default:
+				return Diff{}, ErrProtocol
+			}
+		}
+		pending = next
+	}
+	if err := enc.Encode(request{}); err != nil {
+		return Diff{}, err
+	}
+	diff.sort()
+	return diff, nil
+}
+
+// Serve answers the requests of a Reconcile call on the other end of r and w
+// with the contents of local until the session ends.
+//
+// Returns nil once the session has ended, or the first read or write error.
+func Serve(local *Digest, r io.Reader, w io.Writer, config Config) error {
+	config = config.withDefaults()
+	enc, dec := json.NewEncoder(w), json.NewDecoder(r)
+	for {
+		var req request
+		if err := dec.Decode(&req); err != nil {
+			return err
+		}
+		if len(req.Ranges) == 0 {
+			return nil
+		}
+		resp := response{Replies: make([]reply, len(req.Ranges))}
+		for i, remote := range req.Ranges {
+			resp.Replies[i] = answer(local, remote, config)
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// answer compares the summary of a range sent by the initiator with the
+// local one.
+func answer(local *Digest, remote Summary, config Config) reply {
+	summary := local.Summarize(remote.Range)
+	switch {
+	case summary == remote:
+		return reply{Kind: replyMatch, Range: remote.Range}
+	case summary.Count <= config.ItemThreshold:
+		return reply{Kind: replyItems, Range: remote.Range, Items: local.Elements(remote.Range)}
+	}
+	children := local.Split(remote.Range, config.Branching)
+	rep := reply{Kind: replySplit, Range: remote.Range, Children: make([]Summary, len(children))}
+	for i, child := range children {
+		rep.Children[i] = local.Summarize(child)
+	}
+	return rep
+}
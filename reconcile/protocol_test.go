@@ -0,0 +1,86 @@
+package reconcile
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += len(p)
+	return c.w.Write(p)
+}
+
+// session runs Reconcile against Serve over a pair of in-process pipes and
+// returns the result with the number of bytes both sides wrote.
+func session(t *testing.T, local, remote *Digest) (Diff, int) {
+	t.Helper()
+	requests, requestsW := io.Pipe()
+	responses, responsesW := io.Pipe()
+	reqCount := &countingWriter{w: requestsW}
+	respCount := &countingWriter{w: responsesW}
+
+	done := make(chan error, 1)
+	go func() {
+		err := Serve(remote, requests, respCount, Config{})
+		responsesW.Close()
+		done <- err
+	}()
+	diff, err := Reconcile(local, responses, reqCount)
+	requestsW.Close()
+	if err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Expected Serve to end cleanly, but got %v", err)
+	}
+	return diff, reqCount.n + respCount.n
+}
+
+func TestReconcile(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	local, remote, expected := replicas(rng, 20000, 10)
+
+	diff, traffic := session(t, FromSet(collections.NewSet(local...)), FromOrderedSet(collections.NewOrderedSet(remote...)))
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("Expected %v, but got %v", expected, diff)
+	}
+
+	full := len(strings.Join(remote, `","`))
+	if traffic*5 > full {
+		t.Errorf("Expected far less traffic than the %v bytes of the set, but got %v", full, traffic)
+	}
+}
+
+func TestReconcile_Equal(t *testing.T) {
+	d := New([]string{"a", "b", "c"})
+	diff, traffic := session(t, d, New([]string{"c", "b", "a"}))
+	if !diff.IsEmpty() {
+		t.Errorf("Expected no differences, but got %v", diff)
+	}
+	if traffic > 200 {
+		t.Errorf("Expected a single round for equal sets, but got %v bytes", traffic)
+	}
+}
+
+func TestReconcile_BadPeer(t *testing.T) {
+	var out bytes.Buffer
+	_, err := Reconcile(New([]string{"a"}), strings.NewReader(`{"replies":[]}`), &out)
+	if err != ErrProtocol {
+		t.Errorf("Expected ErrProtocol, but got %v", err)
+	}
+	_, err = Reconcile(New([]string{"a"}), strings.NewReader(""), &out)
+	if err != io.EOF {
+		t.Errorf("Expected io.EOF, but got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package spatial
+
+import "math"
+
+// Point is a position in any number of dimensions, such as {longitude,
+// latitude} or {x, y, z}.
+type Point []float64
+
+// DistanceSquared returns the squared Euclidean distance between p and q.
+func (p Point) DistanceSquared(q Point) float64 {
+	checkDims(len(p), len(q))
+	sum := 0.0
+	for i := range p {
+		d := p[i] - q[i]
+		sum += d * d
+	}
+	return sum
+}
+
+// Distance returns the Euclidean distance between p and q.
+func (p Point) Distance(q Point) float64 {
+	return math.Sqrt(p.DistanceSquared(q))
+}
+
+// Rect is an axis-aligned box. A Rect with Min equal to Max is a point.
+type Rect struct {
+	Min Point
+	Max Point
+}
+
+// NewRect returns the smallest Rect that has a and b as corners.
+func NewRect(a, b Point) Rect {
+	checkDims(len(a), len(b))
+	r := Rect{Min: make(Point, len(a)), Max: make(Point, len(a))}
+	for i := range a {
+		r.Min[i], r.Max[i] = min(a[i], b[i]), max(a[i], b[i])
+	}
+	return r
+}
+
+// PointRect returns the Rect that covers only p.
+func PointRect(p Point) Rect {
+	return NewRect(p, p)
+}
+
+// Dim returns the number of dimensions of r.
+func (r Rect) Dim() int {
+	return len(r.Min)
+}
+
+// Contains checks if p lies inside r or on its border.
+func (r Rect) Contains(p Point) bool {
+	checkDims(r.Dim(), len(p))
+	for i := range p {
+		if p[i] < r.Min[i] || p[i] > r.Max[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsRect checks if other lies entirely inside r.
+func (r Rect) ContainsRect(other Rect) bool {
+	return r.Contains(other.Min) && r.Contains(other.Max)
+}
+
+// Intersects checks if r and other share at least one point.
+func (r Rect) Intersects(other Rect) bool {
+	checkDims(r.Dim(), other.Dim())
+	for i := range r.Min {
+		if other.Max[i] < r.Min[i] || other.Min[i] > r.Max[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns the smallest Rect that covers both r and other.
+func (r Rect) Union(other Rect) Rect {
+	checkDims(r.Dim(), other.Dim())
+	u := Rect{Min: make(Point, r.Dim()), Max: make(Point, r.Dim())}
+	for i := range r.Min {
+		u.Min[i], u.Max[i] = min(r.Min[i], other.Min[i]), max(r.Max[i], other.Max[i])
+	}
+	return u
+}
+
+// Area returns the area of r, or its volume in more than two dimensions.
+func (r Rect) Area() float64 {
+	area := 1.0
+	for i := range r.Min {
+		area *= r.Max[i] - r.Min[i]
+	}
+	return area
+}
+
+// Center returns the middle of r.
+func (r Rect) Center() Point {
+	c := make(Point, r.Dim())
+	for i := range c {
+		c[i] = (r.Min[i] + r.Max[i]) / 2
+	}
+	return c
+}
+
+// DistanceSquared returns the squared distance from p to the closest point
+// of r, which is 0 if r contains p.
+func (r Rect) DistanceSquared(p Point) float64 {
+	checkDims(r.Dim(), len(p))
+	sum := 0.0
+	for i := range p {
+		var d float64
+		if p[i] < r.Min[i] {
+			d = r.Min[i] - p[i]
+		} else if p[i] > r.Max[i] {
+			d = p[i] - r.Max[i]
+		}
+		sum += d * d
+	}
+	return sum
+}
+
+// Distance returns the distance from p to the closest point of r.
+func (r Rect) Distance(p Point) float64 {
+	return math.Sqrt(r.DistanceSquared(p))
+}
+
+func checkDims(a, b int) {
+	if a != b {
+		panic("spatial: dimension mismatch")
+	}
+}
+
+// checkDim rejects points without coordinates, which a k-d tree has no axis
+// to split on.
+func checkDim(d int) {
+	if d == 0 {
+		panic("spatial: point has no dimensions")
+	}
+}
@@ -0,0 +1,42 @@
+package spatial
+
+import "testing"
+
+func TestRect_Geometry(t *testing.T) {
+	r := NewRect(Point{4, 1}, Point{0, 3})
+	if r.Min[0] != 0 || r.Max[0] != 4 || r.Min[1] != 1 || r.Max[1] != 3 {
+		t.Errorf("Expected normalized corners, but got %v", r)
+	}
+	if r.Area() != 8 || r.Center()[0] != 2 || r.Center()[1] != 2 {
+		t.Errorf("Unexpected area %v or center %v", r.Area(), r.Center())
+	}
+
+	if !r.Contains(Point{4, 3}) || r.Contains(Point{5, 2}) {
+		t.Errorf("Contains returned an unexpected result")
+	}
+	if !r.Intersects(NewRect(Point{4, 3}, Point{6, 6})) || r.Intersects(NewRect(Point{5, 0}, Point{6, 6})) {
+		t.Errorf("Intersects returned an unexpected result")
+	}
+	if u := r.Union(PointRect(Point{-1, 5})); !u.ContainsRect(r) || u.Min[0] != -1 || u.Max[1] != 5 {
+		t.Errorf("Unexpected union %v", u)
+	}
+
+	if d := r.Distance(Point{7, 7}); d != 5 {
+		t.Errorf("Expected distance 5, but got %v", d)
+	}
+	if d := r.DistanceSquared(Point{2, 2}); d != 0 {
+		t.Errorf("Expected distance 0 inside the rect, but got %v", d)
+	}
+	if d := (Point{0, 0}).Distance(Point{3, 4}); d != 5 {
+		t.Errorf("Expected distance 5, but got %v", d)
+	}
+}
+
+func TestPoint_DimensionMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for points of different dimensions")
+		}
+	}()
+	Point{1, 2}.Distance(Point{1, 2, 3})
+}
@@ -0,0 +1,242 @@
+package spatial
+
+import (
+	"sort"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+// PointEntry is a point stored in a KDTree together with its payload.
+type PointEntry[T any] struct {
+	Point Point
+	Value T
+}
+
+type kdNode[T any] struct {
+	entry       PointEntry[T]
+	axis        int
+	left, right *kdNode[T]
+}
+
+type KDTree[T any] struct {
+	root *kdNode[T]
+	dim  int
+	size int
+}
+
+// NewKDTree creates an empty k-d tree. The number of dimensions is taken
+// from the first point inserted; every later point must match it.
+//
+// Returns a pointer to the new KDTree.
+func NewKDTree[T any]() *KDTree[T] {
+	return &KDTree[T]{}
+}
+
+// KDTreeFromEntries builds a balanced k-d tree from entries by splitting at
+// the median along each axis in turn.
+//
+// It panics if the entries do not all have the same, non-zero number of
+// dimensions.
+// Returns a pointer to the new KDTree.
+func KDTreeFromEntries[T any](entries []PointEntry[T]) *KDTree[T] {
+	t := &KDTree[T]{size: len(entries)}
+	if len(entries) == 0 {
+		return t
+	}
+	t.dim = len(entries[0].Point)
+	checkDim(t.dim)
+	own := make([]PointEntry[T], len(entries))
+	for i, e := range entries {
+		checkDims(t.dim, len(e.Point))
+		own[i] = PointEntry[T]{Point: append(Point{}, e.Point...), Value: e.Value}
+	}
+	t.root = t.build(own, 0)
+	return t
+}
+
+func (t *KDTree[T]) build(entries []PointEntry[T], depth int) *kdNode[T] {
+	if len(entries) == 0 {
+		return nil
+	}
+	axis := depth % t.dim
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Point[axis] < entries[j].Point[axis]
+	})
+	m := len(entries) / 2
+	return &kdNode[T]{
+		entry: entries[m],
+		axis:  axis,
+		left:  t.build(entries[:m], depth+1),
+		right: t.build(entries[m+1:], depth+1),
+	}
+}
+
+// Insert adds p with its payload. Inserting keeps the tree valid but not
+// balanced; rebuild it with KDTreeFromEntries after many inserts.
+//
+// It panics if p has no dimensions or a different number than the tree.
+func (t *KDTree[T]) Insert(p Point, value T) {
+	if t.root == nil {
+		checkDim(len(p))
+		t.dim = len(p)
+	}
+	checkDims(t.dim, len(p))
+	n := &kdNode[T]{entry: PointEntry[T]{Point: append(Point{}, p...), Value: value}}
+	t.size++
+
+	link := &t.root
+	depth := 0
+	for *link != nil {
+		cur := *link
+		if p[cur.axis] < cur.entry.Point[cur.axis] {
+			link = &cur.left
+		} else {
+			link = &cur.right
+		}
+		depth++
+	}
+	n.axis = depth % t.dim
+	*link = n
+}
+
+// Nearest returns the entry closest to p and false if the tree is empty.
+func (t *KDTree[T]) Nearest(p Point) (PointEntry[T], bool) {
+	nearest := t.KNearest(p, 1)
+	if len(nearest) == 0 {
+		return PointEntry[T]{}, false
+	}
+	return nearest[0], true
+}
+
+type kdCandidate[T any] struct {
+	entry PointEntry[T]
+	dist  float64
+}
+
+// KNearest returns up to k entries closest to p, closest first.
+func (t *KDTree[T]) KNearest(p Point, k int) []PointEntry[T] {
+	if k <= 0 || t.root == nil {
+		return nil
+	}
+	checkDims(t.dim, len(p))
+	// A max-heap of the best candidates so far, farthest on top.
+	best := collections.NewPriorityQueue(func(a, b kdCandidate[T]) bool {
+		return a.dist > b.dist
+	})
+	var visit func(n *kdNode[T])
+	visit = func(n *kdNode[T]) {
+		if n == nil {
+			return
+		}
+		d := p.DistanceSquared(n.entry.Point)
+		if best.Len() < k {
+			best.Push(kdCandidate[T]{n.entry, d})
+		} else if d < best.Peek().dist {
+			best.Pop()
+			best.Push(kdCandidate[T]{n.entry, d})
+		}
+
+		diff := p[n.axis] - n.entry.Point[n.axis]
+		near, far := n.left, n.right
+		if diff >= 0 {
+			near, far = far, near
+		}
+		visit(near)
+		if best.Len() < k || diff*diff <= best.Peek().dist {
+			visit(far)
+		}
+	}
+	visit(t.root)
+
+	result := make([]PointEntry[T], best.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = best.Pop().entry
+	}
+	return result
+}
+
+// Within returns the entries whose points lie inside r or on its border.
+func (t *KDTree[T]) Within(r Rect) []PointEntry[T] {
+	var result []PointEntry[T]
+	if t.root == nil {
+		return result
+	}
+	checkDims(t.dim, r.Dim())
+	var visit func(n *kdNode[T])
+	visit = func(n *kdNode[T]) {
+		if n == nil {
+			return
+		}
+		if r.Contains(n.entry.Point) {
+			result = append(result, n.entry)
+		}
+		v := n.entry.Point[n.axis]
+		if r.Min[n.axis] <= v {
+			visit(n.left)
+		}
+		if r.Max[n.axis] >= v {
+			visit(n.right)
+		}
+	}
+	visit(t.root)
+	return result
+}
+
+// Radius returns the entries whose points lie within radius of center.
+func (t *KDTree[T]) Radius(center Point, radius float64) []PointEntry[T] {
+	var result []PointEntry[T]
+	if t.root == nil {
+		return result
+	}
+	checkDims(t.dim, len(center))
+	r2 := radius * radius
+	var visit func(n *kdNode[T])
+	visit = func(n *kdNode[T]) {
+		if n == nil {
+			return
+		}
+		if center.DistanceSquared(n.entry.Point) <= r2 {
+			result = append(result, n.entry)
+		}
+		diff := center[n.axis] - n.entry.Point[n.axis]
+		if diff <= radius {
+			visit(n.left)
+		}
+		if diff >= -radius {
+			visit(n.right)
+		}
+	}
+	visit(t.root)
+	return result
+}
+
+// ToSlice returns every entry in the tree, in no particular order.
+func (t *KDTree[T]) ToSlice() []PointEntry[T] {
+	result := make([]PointEntry[T], 0, t.size)
+	var visit func(n *kdNode[T])
+	visit = func(n *kdNode[T]) {
+		if n != nil {
+			result = append(result, n.entry)
+			visit(n.left)
+			visit(n.right)
+		}
+	}
+	visit(t.root)
+	return result
+}
+
+// Len returns the number of entries in the tree.
+func (t *KDTree[T]) Len() int {
+	return t.size
+}
+
+// IsEmpty checks if the tree is empty.
+func (t *KDTree[T]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear removes all entries from the tree.
+func (t *KDTree[T]) Clear() {
+	t.root = nil
+	t.size = 0
+}
@@ -0,0 +1,132 @@
+package spatial
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func randomPoints(rng *rand.Rand, n int) []PointEntry[int] {
+	entries := make([]PointEntry[int], n)
+	for i := range entries {
+		entries[i] = PointEntry[int]{Point: Point{rng.Float64() * 100, rng.Float64() * 100}, Value: i}
+	}
+	return entries
+}
+
+func sortedValues(entries []PointEntry[int]) []int {
+	values := make([]int, len(entries))
+	for i, e := range entries {
+		values[i] = e.Value
+	}
+	sort.Ints(values)
+	return values
+}
+
+func TestKDTree_Nearest(t *testing.T) {
+	tree := NewKDTree[string]()
+	tree.Insert(Point{2, 3}, "a")
+	tree.Insert(Point{5, 4}, "b")
+	tree.Insert(Point{9, 6}, "c")
+	tree.Insert(Point{4, 7}, "d")
+	tree.Insert(Point{8, 1}, "e")
+	tree.Insert(Point{7, 2}, "f")
+
+	if e, ok := tree.Nearest(Point{9, 2}); !ok || e.Value != "e" {
+		t.Errorf("Expected e, but got %v %v", e.Value, ok)
+	}
+	nearest := tree.KNearest(Point{7, 2.5}, 3)
+	if len(nearest) != 3 || nearest[0].Value != "f" || nearest[1].Value != "e" || nearest[2].Value != "b" {
+		t.Errorf("Expected [f e b], but got %v", nearest)
+	}
+	if len(tree.KNearest(Point{0, 0}, 10)) != 6 {
+		t.Errorf("Expected KNearest to return every entry when k exceeds the size")
+	}
+	if _, ok := NewKDTree[int]().Nearest(Point{0, 0}); ok {
+		t.Errorf("Expected Nearest to fail on an empty tree")
+	}
+}
+
+func TestKDTree_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(13))
+	entries := randomPoints(rng, 1000)
+	built := KDTreeFromEntries(entries)
+	inserted := NewKDTree[int]()
+	for _, e := range entries {
+		inserted.Insert(e.Point, e.Value)
+	}
+
+	for q := 0; q < 100; q++ {
+		p := Point{rng.Float64() * 100, rng.Float64() * 100}
+		byDistance := append([]PointEntry[int]{}, entries...)
+		sort.Slice(byDistance, func(i, j int) bool {
+			return p.DistanceSquared(byDistance[i].Point) < p.DistanceSquared(byDistance[j].Point)
+		})
+
+		for _, tree := range []*KDTree[int]{built, inserted} {
+			result := tree.KNearest(p, 5)
+			for i := range result {
+				if result[i].Value != byDistance[i].Value {
+					t.Fatalf("KNearest(%v): expected %v, but got %v", p, byDistance[:5], result)
+				}
+			}
+		}
+
+		var inRadius []PointEntry[int]
+		for _, e := range entries {
+			if p.Distance(e.Point) <= 10 {
+				inRadius = append(inRadius, e)
+			}
+		}
+		if result := sortedValues(built.Radius(p, 10)); !equalInts(result, sortedValues(inRadius)) {
+			t.Fatalf("Radius(%v): expected %v, but got %v", p, sortedValues(inRadius), result)
+		}
+
+		box := NewRect(p, Point{p[0] + 15, p[1] + 5})
+		var inBox []PointEntry[int]
+		for _, e := range entries {
+			if box.Contains(e.Point) {
+				inBox = append(inBox, e)
+			}
+		}
+		if result := sortedValues(inserted.Within(box)); !equalInts(result, sortedValues(inBox)) {
+			t.Fatalf("Within(%v): expected %v, but got %v", box, sortedValues(inBox), result)
+		}
+	}
+
+	if built.Len() != 1000 || len(built.ToSlice()) != 1000 {
+		t.Errorf("Expected 1000 entries, but got %v", built.Len())
+	}
+	built.Clear()
+	if !built.IsEmpty() {
+		t.Errorf("Expected empty tree after Clear")
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestKDTree_ZeroDimensions(t *testing.T) {
+	for name, build := range map[string]func(){
+		"Insert":            func() { NewKDTree[int]().Insert(Point{}, 1) },
+		"KDTreeFromEntries": func() { KDTreeFromEntries([]PointEntry[int]{{Point{}, 1}, {Point{}, 2}}) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != "spatial: point has no dimensions" {
+					t.Errorf("%v: expected a panic for a point without dimensions, but got %v", name, r)
+				}
+			}()
+			build()
+		}()
+	}
+}
@@ -0,0 +1,505 @@
+package spatial
+
+import (
+	"math"
+	"sort"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+// RectEntry is a rectangle stored in an RTree together with its payload.
+type RectEntry[T any] struct {
+	Rect  Rect
+	Value T
+}
+
+type rtreeNode[T any] struct {
+	leaf     bool
+	bounds   Rect
+	children []*rtreeNode[T]
+	entries  []RectEntry[T]
+}
+
+func (n *rtreeNode[T]) count() int {
+	if n.leaf {
+		return len(n.entries)
+	}
+	return len(n.children)
+}
+
+func (n *rtreeNode[T]) rect(i int) Rect {
+	if n.leaf {
+		return n.entries[i].Rect
+	}
+	return n.children[i].bounds
+}
+
+func (n *rtreeNode[T]) updateBounds() {
+	if n.count() == 0 {
+		n.bounds = Rect{}
+		return
+	}
+	b := n.rect(0)
+	for i := 1; i < n.count(); i++ {
+		b = b.Union(n.rect(i))
+	}
+	n.bounds = b
+}
+
+type RTree[T any] struct {
+	root       *rtreeNode[T]
+	maxEntries int
+	minEntries int
+	dim        int
+	size       int
+}
+
+// NewRTree creates an empty R-tree whose nodes hold at most maxEntries
+// children. Overflowing nodes are split with Guttman's quadratic split. The
+// number of dimensions is taken from the first rectangle inserted.
+//
+// It panics if maxEntries is less than 2.
+// Returns a pointer to the new RTree.
+func NewRTree[T any](maxEntries int) *RTree[T] {
+	if maxEntries < 2 {
+		panic("spatial: RTree maxEntries must be at least 2")
+	}
+	return &RTree[T]{
+		root:       &rtreeNode[T]{leaf: true},
+		maxEntries: maxEntries,
+		minEntries: max(1, maxEntries*2/5),
+	}
+}
+
+// RTreeFromEntries bulk-loads an R-tree from entries with the
+// Sort-Tile-Recursive algorithm, which packs nodes full and yields far less
+// overlap than inserting the entries one by one.
+//
+// It panics if maxEntries is less than 2 or the entries do not all have the
+// same number of dimensions.
+// Returns a pointer to the new RTree.
+func RTreeFromEntries[T any](maxEntries int, entries []RectEntry[T]) *RTree[T] {
+	t := NewRTree[T](maxEntries)
+	if len(entries) == 0 {
+		return t
+	}
+	t.dim = entries[0].Rect.Dim()
+	own := make([]RectEntry[T], len(entries))
+	for i, e := range entries {
+		checkDims(t.dim, e.Rect.Dim())
+		own[i] = RectEntry[T]{Rect: NewRect(e.Rect.Min, e.Rect.Max), Value: e.Value}
+	}
+	t.size = len(own)
+
+	var level []*rtreeNode[T]
+	for _, group := range strPack(own, func(e RectEntry[T]) Rect { return e.Rect }, 0, t.dim, maxEntries) {
+		n := &rtreeNode[T]{leaf: true, entries: group}
+		n.updateBounds()
+		level = append(level, n)
+	}
+	for len(level) > 1 {
+		var next []*rtreeNode[T]
+		for _, group := range strPack(level, func(n *rtreeNode[T]) Rect { return n.bounds }, 0, t.dim, maxEntries) {
+			n := &rtreeNode[T]{children: group}
+			n.updateBounds()
+			next = append(next, n)
+		}
+		level = next
+	}
+	t.root = level[0]
+	return t
+}
+
+// strPack tiles items into groups of at most capacity: it sorts them by the
+// center along axis, cuts them into slabs and tiles each slab along the next
+// axis.
+func strPack[E any](items []E, rectOf func(E) Rect, axis, dims, capacity int) [][]E {
+	if len(items) <= capacity {
+		return [][]E{items}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := rectOf(items[i]), rectOf(items[j])
+		return a.Min[axis]+a.Max[axis] < b.Min[axis]+b.Max[axis]
+	})
+	pages := (len(items) + capacity - 1) / capacity
+	slabSize := capacity
+	if axis < dims-1 {
+		slabs := int(math.Ceil(math.Pow(float64(pages), 1/float64(dims-axis))))
+		slabSize = capacity * ((pages + slabs - 1) / slabs)
+	}
+
+	var groups [][]E
+	for start := 0; start < len(items); start += slabSize {
+		slab := items[start:min(start+slabSize, len(items))]
+		if axis < dims-1 {
+			groups = append(groups, strPack(slab, rectOf, axis+1, dims, capacity)...)
+		} else {
+			groups = append(groups, slab)
+		}
+	}
+	return groups
+}
+
+// Insert adds r with its payload.
+//
+// It panics if r has a different number of dimensions than the tree.
+func (t *RTree[T]) Insert(r Rect, value T) {
+	if t.size == 0 {
+		t.dim = r.Dim()
+	}
+	checkDims(t.dim, r.Dim())
+	t.insert(RectEntry[T]{Rect: NewRect(r.Min, r.Max), Value: value})
+	t.size++
+}
+
+func (t *RTree[T]) insert(e RectEntry[T]) {
+	path := []*rtreeNode[T]{t.root}
+	n := t.root
+	for !n.leaf {
+		n = chooseSubtree(n, e.Rect)
+		path = append(path, n)
+	}
+	n.entries = append(n.entries, e)
+
+	// Walk back up, splitting full nodes and widening bounds.
+	var split *rtreeNode[T]
+	for i := len(path) - 1; i >= 0; i-- {
+		n := path[i]
+		if split != nil {
+			n.children = append(n.children, split)
+			split = nil
+		}
+		if n.count() > t.maxEntries {
+			split = t.split(n)
+		} else {
+			n.updateBounds()
+		}
+	}
+	if split != nil {
+		root := &rtreeNode[T]{children: []*rtreeNode[T]{t.root, split}}
+		root.updateBounds()
+		t.root = root
+	}
+}
+
+// chooseSubtree picks the child of n whose bounds grow least to cover r,
+// preferring the smaller child on ties.
+func chooseSubtree[T any](n *rtreeNode[T], r Rect) *rtreeNode[T] {
+	best := n.children[0]
+	bestGrowth, bestArea := math.Inf(1), math.Inf(1)
+	for _, c := range n.children {
+		area := c.bounds.Area()
+		growth := c.bounds.Union(r).Area() - area
+		if growth < bestGrowth || (growth == bestGrowth && area < bestArea) {
+			best, bestGrowth, bestArea = c, growth, area
+		}
+	}
+	return best
+}
+
+// split moves part of the children of n into a new sibling using the
+// quadratic split, and returns the sibling.
+func (t *RTree[T]) split(n *rtreeNode[T]) *rtreeNode[T] {
+	count := n.count()
+	rects := make([]Rect, count)
+	for i := range rects {
+		rects[i] = n.rect(i)
+	}
+
+	// Pick the two seeds that would waste the most area together.
+	seedA, seedB, worst := 0, 1, math.Inf(-1)
+	for i := 0; i < count; i++ {
+		for j := i + 1; j < count; j++ {
+			waste := rects[i].Union(rects[j]).Area() - rects[i].Area() - rects[j].Area()
+			if waste > worst {
+				seedA, seedB, worst = i, j, waste
+			}
+		}
+	}
+
+	groupA, groupB := []int{seedA}, []int{seedB}
+	boundsA, boundsB := rects[seedA], rects[seedB]
+	assigned := make([]bool, count)
+	assigned[seedA], assigned[seedB] = true, true
+	for left := count - 2; left > 0; left-- {
+		// Give every remaining rect to a group that would otherwise end up
+		// below the minimum.
+		if len(groupA)+left == t.minEntries || len(groupB)+left == t.minEntries {
+			toA := len(groupA)+left == t.minEntries
+			for i := 0; i < count; i++ {
+				if !assigned[i] {
+					assigned[i] = true
+					if toA {
+						groupA = append(groupA, i)
+					} else {
+						groupB = append(groupB, i)
+					}
+				}
+			}
+			break
+		}
+
+		// Otherwise place the rect with the strongest preference next.
+		next, bestDiff := -1, math.Inf(-1)
+		var growA, growB float64
+		for i := 0; i < count; i++ {
+			if assigned[i] {
+				continue
+			}
+			ga := boundsA.Union(rects[i]).Area() - boundsA.Area()
+			gb := boundsB.Union(rects[i]).Area() - boundsB.Area()
+			if diff := math.Abs(ga - gb); diff > bestDiff {
+				next, bestDiff, growA, growB = i, diff, ga, gb
+			}
+		}
+		assigned[next] = true
+		toA := growA < growB ||
+			(growA == growB && (boundsA.Area() < boundsB.Area() ||
+				(boundsA.Area() == boundsB.Area() && len(groupA) <= len(groupB))))
+		if toA {
+			groupA = append(groupA, next)
+			boundsA = boundsA.Union(rects[next])
+		} else {
+			groupB = append(groupB, next)
+			boundsB = boundsB.Union(rects[next])
+		}
+	}
+
+	sibling := &rtreeNode[T]{leaf: n.leaf}
+	if n.leaf {
+		entries := n.entries
+		n.entries = pick(entries, groupA)
+		sibling.entries = pick(entries, groupB)
+	} else {
+		children := n.children
+		n.children = pick(children, groupA)
+		sibling.children = pick(children, groupB)
+	}
+	n.updateBounds()
+	sibling.updateBounds()
+	return sibling
+}
+
+func pick[E any](items []E, indexes []int) []E {
+	result := make([]E, len(indexes))
+	for i, idx := range indexes {
+		result[i] = items[idx]
+	}
+	return result
+}
+
+// Delete removes the first entry whose rectangle equals r and whose payload
+// satisfies match. A nil match accepts any payload.
+//
+// Returns false if no such entry was found.
+func (t *RTree[T]) Delete(r Rect, match func(value T) bool) bool {
+	if t.size == 0 {
+		return false
+	}
+	checkDims(t.dim, r.Dim())
+	r = NewRect(r.Min, r.Max)
+	path, index := t.findEntry(t.root, r, match, nil)
+	if path == nil {
+		return false
+	}
+	leaf := path[len(path)-1]
+	leaf.entries = append(leaf.entries[:index], leaf.entries[index+1:]...)
+	t.size--
+
+	// Dissolve nodes that fell below the minimum and reinsert their entries.
+	var orphans []RectEntry[T]
+	for i := len(path) - 1; i > 0; i-- {
+		n, parent := path[i], path[i-1]
+		if n.count() < t.minEntries {
+			for j, c := range parent.children {
+				if c == n {
+					parent.children = append(parent.children[:j], parent.children[j+1:]...)
+					break
+				}
+			}
+			orphans = collectEntries(n, orphans)
+		} else {
+			n.updateBounds()
+		}
+	}
+	t.root.updateBounds()
+	for !t.root.leaf && len(t.root.children) == 1 {
+		t.root = t.root.children[0]
+	}
+	if !t.root.leaf && len(t.root.children) == 0 {
+		t.root = &rtreeNode[T]{leaf: true}
+	}
+	for _, e := range orphans {
+		t.insert(e)
+	}
+	return true
+}
+
+func (t *RTree[T]) findEntry(n *rtreeNode[T], r Rect, match func(T) bool, path []*rtreeNode[T]) ([]*rtreeNode[T], int) {
+	path = append(path, n)
+	if n.leaf {
+		for i, e := range n.entries {
+			if rectEqual(e.Rect, r) && (match == nil || match(e.Value)) {
+				return path, i
+			}
+		}
+		return nil, -1
+	}
+	for _, c := range n.children {
+		if c.bounds.ContainsRect(r) {
+			if found, i := t.findEntry(c, r, match, path); found != nil {
+				return found, i
+			}
+		}
+	}
+	return nil, -1
+}
+
+func rectEqual(a, b Rect) bool {
+	for i := range a.Min {
+		if a.Min[i] != b.Min[i] || a.Max[i] != b.Max[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func collectEntries[T any](n *rtreeNode[T], into []RectEntry[T]) []RectEntry[T] {
+	if n.leaf {
+		return append(into, n.entries...)
+	}
+	for _, c := range n.children {
+		into = collectEntries(c, into)
+	}
+	return into
+}
+
+// Search returns the entries whose rectangles intersect r.
+func (t *RTree[T]) Search(r Rect) []RectEntry[T] {
+	var result []RectEntry[T]
+	if t.size == 0 {
+		return result
+	}
+	checkDims(t.dim, r.Dim())
+	var visit func(n *rtreeNode[T])
+	visit = func(n *rtreeNode[T]) {
+		if n.leaf {
+			for _, e := range n.entries {
+				if e.Rect.Intersects(r) {
+					result = append(result, e)
+				}
+			}
+			return
+		}
+		for _, c := range n.children {
+			if c.bounds.Intersects(r) {
+				visit(c)
+			}
+		}
+	}
+	visit(t.root)
+	return result
+}
+
+// Radius returns the entries whose rectangles come within radius of center.
+func (t *RTree[T]) Radius(center Point, radius float64) []RectEntry[T] {
+	var result []RectEntry[T]
+	if t.size == 0 {
+		return result
+	}
+	checkDims(t.dim, len(center))
+	r2 := radius * radius
+	var visit func(n *rtreeNode[T])
+	visit = func(n *rtreeNode[T]) {
+		if n.leaf {
+			for _, e := range n.entries {
+				if e.Rect.DistanceSquared(center) <= r2 {
+					result = append(result, e)
+				}
+			}
+			return
+		}
+		for _, c := range n.children {
+			if c.bounds.DistanceSquared(center) <= r2 {
+				visit(c)
+			}
+		}
+	}
+	visit(t.root)
+	return result
+}
+
+// Nearest returns the entry whose rectangle is closest to p and false if the
+// tree is empty.
+func (t *RTree[T]) Nearest(p Point) (RectEntry[T], bool) {
+	nearest := t.KNearest(p, 1)
+	if len(nearest) == 0 {
+		return RectEntry[T]{}, false
+	}
+	return nearest[0], true
+}
+
+type rtreeCandidate[T any] struct {
+	dist  float64
+	node  *rtreeNode[T]
+	entry RectEntry[T]
+}
+
+// KNearest returns up to k entries whose rectangles are closest to p,
+// closest first. Nodes are visited best-first, so only the part of the tree
+// that can hold the answer is read.
+func (t *RTree[T]) KNearest(p Point, k int) []RectEntry[T] {
+	if k <= 0 || t.size == 0 {
+		return nil
+	}
+	checkDims(t.dim, len(p))
+	pq := collections.NewPriorityQueue(func(a, b rtreeCandidate[T]) bool {
+		return a.dist < b.dist
+	})
+	pq.Push(rtreeCandidate[T]{dist: t.root.bounds.DistanceSquared(p), node: t.root})
+
+	result := make([]RectEntry[T], 0, k)
+	for !pq.IsEmpty() && len(result) < k {
+		c := pq.Pop()
+		switch {
+		case c.node == nil:
+			result = append(result, c.entry)
+		case c.node.leaf:
+			for _, e := range c.node.entries {
+				pq.Push(rtreeCandidate[T]{dist: e.Rect.DistanceSquared(p), entry: e})
+			}
+		default:
+			for _, child := range c.node.children {
+				pq.Push(rtreeCandidate[T]{dist: child.bounds.DistanceSquared(p), node: child})
+			}
+		}
+	}
+	return result
+}
+
+// Bounds returns the smallest Rect covering every entry, or an empty Rect if
+// the tree is empty.
+func (t *RTree[T]) Bounds() Rect {
+	return t.root.bounds
+}
+
+// ToSlice returns every entry in the tree, in no particular order.
+func (t *RTree[T]) ToSlice() []RectEntry[T] {
+	return collectEntries(t.root, make([]RectEntry[T], 0, t.size))
+}
+
+// Len returns the number of entries in the tree.
+func (t *RTree[T]) Len() int {
+	return t.size
+}
+
+// IsEmpty checks if the tree is empty.
+func (t *RTree[T]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear removes all entries from the tree.
+func (t *RTree[T]) Clear() {
+	t.root = &rtreeNode[T]{leaf: true}
+	t.size = 0
+}
@@ -0,0 +1,160 @@
+package spatial
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func randomRects(rng *rand.Rand, n int) []RectEntry[int] {
+	entries := make([]RectEntry[int], n)
+	for i := range entries {
+		x, y := rng.Float64()*100, rng.Float64()*100
+		entries[i] = RectEntry[int]{
+			Rect:  NewRect(Point{x, y}, Point{x + rng.Float64()*5, y + rng.Float64()*5}),
+			Value: i,
+		}
+	}
+	return entries
+}
+
+func rectValues(entries []RectEntry[int]) []int {
+	values := make([]int, len(entries))
+	for i, e := range entries {
+		values[i] = e.Value
+	}
+	sort.Ints(values)
+	return values
+}
+
+// checkRTree verifies that every node covers its children and that all
+// leaves are at the same depth.
+func checkRTree[T any](t *testing.T, tree *RTree[T]) {
+	t.Helper()
+	leafDepth := -1
+	var visit func(n *rtreeNode[T], depth int)
+	visit = func(n *rtreeNode[T], depth int) {
+		if n.count() > tree.maxEntries {
+			t.Fatalf("Node with %v children exceeds the maximum", n.count())
+		}
+		for i := 0; i < n.count(); i++ {
+			if !n.bounds.ContainsRect(n.rect(i)) {
+				t.Fatalf("Node bounds %v do not cover child %v", n.bounds, n.rect(i))
+			}
+		}
+		if n.leaf {
+			if leafDepth == -1 {
+				leafDepth = depth
+			} else if leafDepth != depth {
+				t.Fatalf("Leaves at depths %v and %v", leafDepth, depth)
+			}
+			return
+		}
+		for _, c := range n.children {
+			visit(c, depth+1)
+		}
+	}
+	visit(tree.root, 0)
+}
+
+func TestRTree_Search(t *testing.T) {
+	tree := NewRTree[string](4)
+	tree.Insert(NewRect(Point{0, 0}, Point{2, 2}), "a")
+	tree.Insert(NewRect(Point{5, 5}, Point{7, 7}), "b")
+	tree.Insert(PointRect(Point{10, 1}), "c")
+	tree.Insert(NewRect(Point{1, 1}, Point{6, 6}), "d")
+	tree.Insert(NewRect(Point{20, 20}, Point{21, 21}), "e")
+
+	var found []string
+	for _, e := range tree.Search(NewRect(Point{2, 2}, Point{5, 5})) {
+		found = append(found, e.Value)
+	}
+	sort.Strings(found)
+	if len(found) != 3 || found[0] != "a" || found[1] != "b" || found[2] != "d" {
+		t.Errorf("Expected [a b d], but got %v", found)
+	}
+
+	if e, ok := tree.Nearest(Point{11, 1}); !ok || e.Value != "c" {
+		t.Errorf("Expected c, but got %v %v", e.Value, ok)
+	}
+	if b := tree.Bounds(); b.Min[0] != 0 || b.Max[0] != 21 {
+		t.Errorf("Unexpected bounds %v", b)
+	}
+
+	if !tree.Delete(NewRect(Point{5, 5}, Point{7, 7}), nil) || tree.Delete(NewRect(Point{5, 5}, Point{7, 7}), nil) {
+		t.Errorf("Expected exactly one successful delete")
+	}
+	if !tree.Delete(PointRect(Point{10, 1}), func(v string) bool { return v == "c" }) {
+		t.Errorf("Expected delete with a matching payload to succeed")
+	}
+	if tree.Len() != 3 {
+		t.Errorf("Expected 3 entries, but got %v", tree.Len())
+	}
+	checkRTree(t, tree)
+}
+
+func TestRTree_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(17))
+	entries := randomRects(rng, 2000)
+	bulk := RTreeFromEntries(8, entries)
+	inserted := NewRTree[int](8)
+	for _, e := range entries {
+		inserted.Insert(e.Rect, e.Value)
+	}
+	checkRTree(t, bulk)
+	checkRTree(t, inserted)
+
+	// Delete every third entry from the incrementally built tree.
+	var remaining []RectEntry[int]
+	for i, e := range entries {
+		if i%3 == 0 {
+			value := e.Value
+			if !inserted.Delete(e.Rect, func(v int) bool { return v == value }) {
+				t.Fatalf("Expected to delete entry %v", e.Value)
+			}
+		} else {
+			remaining = append(remaining, e)
+		}
+	}
+	checkRTree(t, inserted)
+	if inserted.Len() != len(remaining) || len(inserted.ToSlice()) != len(remaining) {
+		t.Fatalf("Expected %v entries, but got %v", len(remaining), inserted.Len())
+	}
+
+	for q := 0; q < 100; q++ {
+		p := Point{rng.Float64() * 100, rng.Float64() * 100}
+		box := NewRect(p, Point{p[0] + 10, p[1] + 10})
+
+		for _, c := range []struct {
+			tree    *RTree[int]
+			entries []RectEntry[int]
+		}{{bulk, entries}, {inserted, remaining}} {
+			var inBox, inRadius []RectEntry[int]
+			for _, e := range c.entries {
+				if e.Rect.Intersects(box) {
+					inBox = append(inBox, e)
+				}
+				if e.Rect.Distance(p) <= 8 {
+					inRadius = append(inRadius, e)
+				}
+			}
+			if result := rectValues(c.tree.Search(box)); !equalInts(result, rectValues(inBox)) {
+				t.Fatalf("Search(%v): expected %v, but got %v", box, rectValues(inBox), result)
+			}
+			if result := rectValues(c.tree.Radius(p, 8)); !equalInts(result, rectValues(inRadius)) {
+				t.Fatalf("Radius(%v): expected %v, but got %v", p, rectValues(inRadius), result)
+			}
+
+			byDistance := append([]RectEntry[int]{}, c.entries...)
+			sort.SliceStable(byDistance, func(i, j int) bool {
+				return byDistance[i].Rect.DistanceSquared(p) < byDistance[j].Rect.DistanceSquared(p)
+			})
+			result := c.tree.KNearest(p, 4)
+			for i := range result {
+				if result[i].Rect.DistanceSquared(p) != byDistance[i].Rect.DistanceSquared(p) {
+					t.Fatalf("KNearest(%v): result %v is not the %v-th closest", p, result[i], i)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package table
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+// Index is a secondary index of a table. It is created with
+// NewUniqueIndex, NewHashIndex or NewSortedIndex and passed to New, after
+// which the table keeps it up to date.
+type Index[R any] interface {
+	Name() string
+	attach(owner any, slot int)
+	owner() any
+	check(record R, self *row[R]) error
+	insert(r *row[R])
+	remove(r *row[R])
+	clear()
+}
+
+// Predicate is a condition on records that can be answered by the index it
+// was built from.
+type Predicate[R any] struct {
+	index Index[R]
+	match func(record R) bool
+	// estimate counts the rows the index yields, stopping at limit.
+	estimate func(limit int) int
+	// scan calls fn for the rows the index yields until fn returns false.
+	scan func(fn func(r *row[R]) bool)
+	// ordered is true if scan yields rows in the order of the index.
+	ordered bool
+}
+
+type indexBase struct {
+	name  string
+	table any
+	// slot is the position of the index in its table and of its keys in
+	// row.keys.
+	slot int
+}
+
+// Name returns the name of the index.
+func (b *indexBase) Name() string {
+	return b.name
+}
+
+func (b *indexBase) attach(owner any, slot int) {
+	if b.table != nil {
+		panic(fmt.Sprintf("table: index %q already belongs to a table", b.name))
+	}
+	b.table, b.slot = owner, slot
+}
+
+func (b *indexBase) owner() any {
+	return b.table
+}
+
+type UniqueIndex[R any, V comparable] struct {
+	indexBase
+	key  func(R) V
+	rows map[V]*row[R]
+}
+
+// NewUniqueIndex creates a hash index on key that allows at most one record
+// per key.
+//
+// Returns a pointer to the new UniqueIndex.
+func NewUniqueIndex[R any, V comparable](name string, key func(R) V) *UniqueIndex[R, V] {
+	return &UniqueIndex[R, V]{indexBase: indexBase{name: name}, key: key, rows: make(map[V]*row[R])}
+}
+
+// Eq matches the record whose key equals v.
+func (idx *UniqueIndex[R, V]) Eq(v V) Predicate[R] {
+	return Predicate[R]{
+		index: idx,
+		match: func(record R) bool { return idx.key(record) == v },
+		estimate: func(limit int) int {
+			if _, ok := idx.rows[v]; ok {
+				return min(1, limit)
+			}
+			return 0
+		},
+		scan: func(fn func(r *row[R]) bool) {
+			if r, ok := idx.rows[v]; ok {
+				fn(r)
+			}
+		},
+	}
+}
+
+func (idx *UniqueIndex[R, V]) check(record R, self *row[R]) error {
+	if r, ok := idx.rows[idx.key(record)]; ok && r != self {
+		return fmt.Errorf("%w: %s", ErrUniqueViolation, idx.name)
+	}
+	return nil
+}
+
+func (idx *UniqueIndex[R, V]) insert(r *row[R]) {
+	v := idx.key(r.record)
+	r.keys[idx.slot] = v
+	idx.rows[v] = r
+}
+
+func (idx *UniqueIndex[R, V]) remove(r *row[R]) {
+	delete(idx.rows, r.keys[idx.slot].(V))
+}
+
+func (idx *UniqueIndex[R, V]) clear() {
+	idx.rows = make(map[V]*row[R])
+}
+
+type HashIndex[R any, V comparable] struct {
+	indexBase
+	key  func(R) V
+	rows map[V]collections.Set[*row[R]]
+}
+
+// NewHashIndex creates a hash index on key that allows any number of records
+// per key.
+//
+// Returns a pointer to the new HashIndex.
+func NewHashIndex[R any, V comparable](name string, key func(R) V) *HashIndex[R, V] {
+	return &HashIndex[R, V]{indexBase: indexBase{name: name}, key: key, rows: make(map[V]collections.Set[*row[R]])}
+}
+
+// Eq matches the records whose key equals v.
+func (idx *HashIndex[R, V]) Eq(v V) Predicate[R] {
+	return Predicate[R]{
+		index: idx,
+		match: func(record R) bool { return idx.key(record) == v },
+		estimate: func(limit int) int {
+			return min(idx.rows[v].Len(), limit)
+		},
+		scan: func(fn func(r *row[R]) bool) {
+			rows := idx.rows[v].ToSlice()
+			sort.Slice(rows, func(i, j int) bool { return rows[i].seq < rows[j].seq })
+			for _, r := range rows {
+				if !fn(r) {
+					return
+				}
+			}
+		},
+	}
+}
+
+func (idx *HashIndex[R, V]) check(R, *row[R]) error {
+	return nil
+}
+
+func (idx *HashIndex[R, V]) insert(r *row[R]) {
+	v := idx.key(r.record)
+	r.keys[idx.slot] = v
+	rows, ok := idx.rows[v]
+	if !ok {
+		rows = collections.NewSet[*row[R]]()
+		idx.rows[v] = rows
+	}
+	rows.Add(r)
+}
+
+func (idx *HashIndex[R, V]) remove(r *row[R]) {
+	v := r.keys[idx.slot].(V)
+	rows := idx.rows[v]
+	rows.Remove(r)
+	if rows.IsEmpty() {
+		delete(idx.rows, v)
+	}
+}
+
+func (idx *HashIndex[R, V]) clear() {
+	idx.rows = make(map[V]collections.Set[*row[R]])
+}
+
+// sortKey orders the rows of a sorted index by key, then by insertion.
+type sortKey[V cmp.Ordered] struct {
+	v   V
+	seq uint64
+}
+
+type SortedIndex[R any, V cmp.Ordered] struct {
+	indexBase
+	key  func(R) V
+	rows *collections.SkipList[sortKey[V], *row[R]]
+}
+
+// NewSortedIndex creates an index that keeps records ordered by key, which
+// answers range predicates and orders query results. Records with equal keys
+// keep their insertion order.
+//
+// Returns a pointer to the new SortedIndex.
+func NewSortedIndex[R any, V cmp.Ordered](name string, key func(R) V) *SortedIndex[R, V] {
+	return &SortedIndex[R, V]{indexBase: indexBase{name: name}, key: key, rows: newSortedRows[R, V]()}
+}
+
+func newSortedRows[R any, V cmp.Ordered]() *collections.SkipList[sortKey[V], *row[R]] {
+	return collections.NewSkipList[sortKey[V], *row[R]](func(a, b sortKey[V]) bool {
+		if c := cmp.Compare(a.v, b.v); c != 0 {
+			return c < 0
+		}
+		return a.seq < b.seq
+	})
+}
+
+// Eq matches the records whose key equals v.
+func (idx *SortedIndex[R, V]) Eq(v V) Predicate[R] {
+	return idx.between(&v, &v, true)
+}
+
+// Between matches the records whose key is in [from, to).
+func (idx *SortedIndex[R, V]) Between(from, to V) Predicate[R] {
+	return idx.between(&from, &to, false)
+}
+
+// AtLeast matches the records whose key is greater than or equal to v.
+func (idx *SortedIndex[R, V]) AtLeast(v V) Predicate[R] {
+	return idx.between(&v, nil, false)
+}
+
+// LessThan matches the records whose key is less than v.
+func (idx *SortedIndex[R, V]) LessThan(v V) Predicate[R] {
+	return idx.between(nil, &v, false)
+}
+
+// between matches the keys from from up to to, either of which may be nil
+// for no bound.
+func (idx *SortedIndex[R, V]) between(from, to *V, inclusive bool) Predicate[R] {
+	below := func(v V) bool {
+		if to == nil {
+			return true
+		}
+		c := cmp.Compare(v, *to)
+		return c < 0 || inclusive && c == 0
+	}
+	scan := func(fn func(r *row[R]) bool) {
+		it := idx.rows.Iterator()
+		if from == nil {
+			it.SeekFirst()
+		} else {
+			it.Seek(sortKey[V]{v: *from})
+		}
+		for ; it.Valid() && below(it.Key().v); it.Next() {
+			if !fn(it.Value()) {
+				return
+			}
+		}
+	}
+	return Predicate[R]{
+		index: idx,
+		match: func(record R) bool {
+			v := idx.key(record)
+			return (from == nil || cmp.Compare(v, *from) >= 0) && below(v)
+		},
+		estimate: func(limit int) int {
+			n := 0
+			scan(func(*row[R]) bool {
+				n++
+				return n < limit
+			})
+			return n
+		},
+		scan:    scan,
+		ordered: true,
+	}
+}
+
+func (idx *SortedIndex[R, V]) check(R, *row[R]) error {
+	return nil
+}
+
+func (idx *SortedIndex[R, V]) insert(r *row[R]) {
+	v := idx.key(r.record)
+	r.keys[idx.slot] = v
+	idx.rows.Put(sortKey[V]{v, r.seq}, r)
+}
+
+func (idx *SortedIndex[R, V]) remove(r *row[R]) {
+	idx.rows.Delete(sortKey[V]{r.keys[idx.slot].(V), r.seq})
+}
+
+func (idx *SortedIndex[R, V]) clear() {
+	idx.rows.Clear()
+}
+
+// each calls fn for every row in index order until fn returns false.
+func (idx *SortedIndex[R, V]) each(fn func(r *row[R]) bool) {
+	idx.rows.Each(func(_ sortKey[V], r *row[R]) bool {
+		return fn(r)
+	})
+}
+
+// less orders two rows as the index does, by the keys they were indexed
+// under.
+func (idx *SortedIndex[R, V]) less(a, b *row[R]) bool {
+	if c := cmp.Compare(a.keys[idx.slot].(V), b.keys[idx.slot].(V)); c != 0 {
+		return c < 0
+	}
+	return a.seq < b.seq
+}
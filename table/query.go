@@ -0,0 +1,196 @@
+package table
+
+import (
+	"slices"
+	"sort"
+)
+
+// Order is an index that can order query results. SortedIndex implements
+// it.
+type Order[R any] interface {
+	Index[R]
+	each(fn func(r *row[R]) bool)
+	less(a, b *row[R]) bool
+}
+
+type Query[PK comparable, R any] struct {
+	table   *Table[PK, R]
+	preds   []Predicate[R]
+	order   Order[R]
+	reverse bool
+	limit   int
+}
+
+// Select starts a query for the records that match all predicates. The
+// query reads through the index of the predicate that yields the fewest
+// records and checks the other predicates on those records only.
+//
+// It panics if a predicate comes from an index of another table.
+// Returns a pointer to the new Query.
+func (t *Table[PK, R]) Select(preds ...Predicate[R]) *Query[PK, R] {
+	for _, p := range preds {
+		t.own(p.index)
+	}
+	return &Query[PK, R]{table: t, preds: preds, limit: -1}
+}
+
+// OrderBy returns the results in the order of index instead of insertion
+// order.
+//
+// It panics if index belongs to another table.
+func (q *Query[PK, R]) OrderBy(index Order[R]) *Query[PK, R] {
+	q.table.own(index)
+	q.order = index
+	return q
+}
+
+// Reverse returns the results in reverse order.
+func (q *Query[PK, R]) Reverse() *Query[PK, R] {
+	q.reverse = !q.reverse
+	return q
+}
+
+// Limit returns at most n results.
+func (q *Query[PK, R]) Limit(n int) *Query[PK, R] {
+	q.limit = max(0, n)
+	return q
+}
+
+// Plan describes how the query reads the table: "index <name>" when it
+// reads through the index of a predicate, "order <name>" when it walks the
+// ordering index, or "scan" when it reads every record.
+func (q *Query[PK, R]) Plan() string {
+	if i := q.driver(); i >= 0 {
+		return "index " + q.preds[i].index.Name()
+	}
+	if q.order != nil {
+		return "order " + q.order.Name()
+	}
+	return "scan"
+}
+
+// Each calls fn for every result until fn returns false. The table must not
+// be changed from fn.
+func (q *Query[PK, R]) Each(fn func(record R) bool) {
+	if q.limit == 0 {
+		return
+	}
+	source, sorted := q.source()
+	if sorted && !q.reverse {
+		n := 0
+		source(func(r *row[R]) bool {
+			if !q.match(r.record) {
+				return true
+			}
+			n++
+			return fn(r.record) && n != q.limit
+		})
+		return
+	}
+
+	var rows []*row[R]
+	source(func(r *row[R]) bool {
+		if q.match(r.record) {
+			rows = append(rows, r)
+		}
+		return true
+	})
+	if !sorted {
+		sort.SliceStable(rows, func(i, j int) bool { return q.less(rows[i], rows[j]) })
+	}
+	if q.reverse {
+		slices.Reverse(rows)
+	}
+	if q.limit > 0 && len(rows) > q.limit {
+		rows = rows[:q.limit]
+	}
+	for _, r := range rows {
+		if !fn(r.record) {
+			return
+		}
+	}
+}
+
+// All returns the results.
+func (q *Query[PK, R]) All() []R {
+	var records []R
+	q.Each(func(record R) bool {
+		records = append(records, record)
+		return true
+	})
+	return records
+}
+
+// First returns the first result and true, or false if there are none.
+func (q *Query[PK, R]) First() (R, bool) {
+	var first R
+	found := false
+	q.Each(func(record R) bool {
+		first, found = record, true
+		return false
+	})
+	return first, found
+}
+
+// Count returns the number of results.
+func (q *Query[PK, R]) Count() int {
+	n := 0
+	q.Each(func(R) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// driver returns the predicate whose index yields the fewest rows, or -1 if
+// none yields fewer than a full scan.
+func (q *Query[PK, R]) driver() int {
+	best, cost := -1, q.table.Len()
+	for i, p := range q.preds {
+		if n := p.estimate(cost); n < cost {
+			best, cost = i, n
+		}
+	}
+	return best
+}
+
+// source returns the rows to read and whether they already come in result
+// order.
+func (q *Query[PK, R]) source() (func(fn func(r *row[R]) bool), bool) {
+	if i := q.driver(); i >= 0 {
+		p := q.preds[i]
+		if q.order != nil {
+			return p.scan, p.ordered && Index[R](q.order) == p.index
+		}
+		return p.scan, !p.ordered
+	}
+	if q.order != nil {
+		return q.order.each, true
+	}
+	return func(fn func(r *row[R]) bool) {
+		q.table.order.Each(func(_ uint64, r *row[R]) bool { return fn(r) })
+	}, true
+}
+
+func (q *Query[PK, R]) match(record R) bool {
+	for _, p := range q.preds {
+		if !p.match(record) {
+			return false
+		}
+	}
+	return true
+}
+
+// less orders rows by the ordering index, or by insertion without one.
+func (q *Query[PK, R]) less(a, b *row[R]) bool {
+	if q.order != nil {
+		return q.order.less(a, b)
+	}
+	return a.seq < b.seq
+}
+
+func (t *Table[PK, R]) own(index Index[R]) {
+	if index.owner() != any(t) {
+		panic("table: index " + index.Name() + " belongs to another table")
+	}
+}
@@ -0,0 +1,132 @@
+package table
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestQuery_Plan(t *testing.T) {
+	u := newUsers()
+	for i := 0; i < 100; i++ {
+		status := "active"
+		if i%10 == 0 {
+			status = "banned"
+		}
+		u.Insert(user{i, string(rune('a'+i%26)) + string(rune('a'+i/26)), status, i})
+	}
+
+	tests := []struct {
+		query *Query[int, user]
+		plan  string
+		count int
+	}{
+		{u.Select(u.email.Eq("cb"), u.status.Eq("active")), "index email", 1},
+		{u.Select(u.status.Eq("banned"), u.age.AtLeast(20)), "index status", 8},
+		{u.Select(u.status.Eq("active"), u.age.Between(20, 25)), "index age", 4},
+		{u.Select(u.status.Eq("active")), "index status", 90},
+		{u.Select(u.age.LessThan(1000)), "scan", 100},
+		{u.Select().OrderBy(u.age), "order age", 100},
+		{u.Select(u.email.Eq("missing")), "index email", 0},
+	}
+	for _, test := range tests {
+		if plan := test.query.Plan(); plan != test.plan {
+			t.Errorf("Expected plan %v, but got %v", test.plan, plan)
+		}
+		if count := test.query.Count(); count != test.count {
+			t.Errorf("Expected %v results for %v, but got %v", test.count, test.plan, count)
+		}
+	}
+}
+
+func TestQuery_Order(t *testing.T) {
+	u := newUsers(
+		user{1, "a", "active", 40},
+		user{2, "b", "banned", 20},
+		user{3, "c", "active", 30},
+		user{4, "d", "active", 20},
+		user{5, "e", "banned", 50},
+	)
+
+	if result := ids(u.Select(u.status.Eq("active")).OrderBy(u.age).All()); !reflect.DeepEqual(result, []int{4, 3, 1}) {
+		t.Errorf("Expected [4 3 1], but got %v", result)
+	}
+	if result := ids(u.Select(u.age.AtLeast(20)).OrderBy(u.age).Reverse().Limit(3).All()); !reflect.DeepEqual(result, []int{5, 1, 3}) {
+		t.Errorf("Expected [5 1 3], but got %v", result)
+	}
+	// Without OrderBy, results come in insertion order whatever the index.
+	if result := ids(u.Select(u.age.Between(20, 35), u.status.Eq("active")).All()); !reflect.DeepEqual(result, []int{3, 4}) {
+		t.Errorf("Expected [3 4], but got %v", result)
+	}
+	if result := ids(u.Select(u.age.Eq(20)).Limit(1).All()); !reflect.DeepEqual(result, []int{2}) {
+		t.Errorf("Expected [2], but got %v", result)
+	}
+	if r, ok := u.Select().OrderBy(u.age).Reverse().First(); !ok || r.ID != 5 {
+		t.Errorf("Expected 5, but got %v", r)
+	}
+	if u.Select(u.status.Eq("active")).Limit(0).Count() != 0 {
+		t.Errorf("Expected no results with Limit(0)")
+	}
+}
+
+func TestQuery_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	u := newUsers()
+	expected := map[int]user{}
+	statuses := []string{"active", "banned", "pending"}
+	for i := 0; i < 3000; i++ {
+		id := rng.Intn(300)
+		switch rng.Intn(3) {
+		case 0:
+			u.Delete(id)
+			delete(expected, id)
+		default:
+			r := user{id, "u" + string(rune('a'+id%26)) + string(rune('a'+id/26)), statuses[rng.Intn(3)], rng.Intn(80)}
+			if err := u.Upsert(r); err != nil {
+				t.Fatalf("Expected nil, but got %v", err)
+			}
+			expected[id] = r
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		status := statuses[rng.Intn(3)]
+		from := rng.Intn(80)
+		to := from + rng.Intn(20)
+		var want []user
+		for _, r := range expected {
+			if r.Status == status && r.Age >= from && r.Age < to {
+				want = append(want, r)
+			}
+		}
+		byAge := func(records []user) {
+			sort.Slice(records, func(i, j int) bool {
+				if records[i].Age != records[j].Age {
+					return records[i].Age < records[j].Age
+				}
+				return records[i].ID < records[j].ID
+			})
+		}
+		byAge(want)
+
+		got := u.Select(u.status.Eq(status), u.age.Between(from, to)).OrderBy(u.age).All()
+		if !sort.SliceIsSorted(got, func(i, j int) bool { return got[i].Age < got[j].Age }) {
+			t.Fatalf("Expected results ordered by age, but got %v", got)
+		}
+		byAge(got)
+		if len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+			t.Fatalf("Expected %v, but got %v", ids(want), ids(got))
+		}
+	}
+}
+
+func TestSelect_ForeignIndex(t *testing.T) {
+	a, b := newUsers(), newUsers()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for an index of another table")
+		}
+	}()
+	a.Select(b.status.Eq("active"))
+}
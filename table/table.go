@@ -0,0 +1,206 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kxrxh/goloom/collections"
+)
+
+var (
+	// ErrDuplicateKey is returned by Insert when a record with the same
+	// primary key exists.
+	ErrDuplicateKey = errors.New("table: duplicate primary key")
+	// ErrNotFound is returned by Update when no record has the primary key.
+	ErrNotFound = errors.New("table: record not found")
+	// ErrUniqueViolation is returned, wrapped with the index name, when a
+	// write would give two records the same key in a unique index.
+	ErrUniqueViolation = errors.New("table: unique index violation")
+)
+
+// row holds a record. Indexes point to rows, so an update that keeps the
+// primary key keeps the row and its position in insertion order. keys holds
+// the key of the record in each index, by the position of the index in the
+// table, so that the row can be removed even if a pointer record was changed
+// in place before Update.
+type row[R any] struct {
+	record R
+	seq    uint64
+	keys   []any
+}
+
+type Table[PK comparable, R any] struct {
+	key     func(R) PK
+	indexes []Index[R]
+	rows    map[PK]*row[R]
+	order   *collections.SkipList[uint64, *row[R]]
+	seq     uint64
+}
+
+// New creates an empty table whose records are identified by key and kept
+// up to date in the given secondary indexes. An index can only belong to
+// one table.
+//
+// A Table is not safe for concurrent use.
+// It panics if an index is already attached to a table or two indexes share
+// a name.
+// Returns a pointer to the new Table.
+func New[PK comparable, R any](key func(R) PK, indexes ...Index[R]) *Table[PK, R] {
+	t := &Table[PK, R]{
+		key:     key,
+		indexes: indexes,
+		rows:    make(map[PK]*row[R]),
+		order:   collections.NewOrderedSkipList[uint64, *row[R]](),
+	}
+	names := collections.NewSet[string]()
+	for i, idx := range indexes {
+		if names.Contains(idx.Name()) {
+			panic(fmt.Sprintf("table: duplicate index name %q", idx.Name()))
+		}
+		names.Add(idx.Name())
+		idx.attach(t, i)
+	}
+	return t
+}
+
+// Insert adds record to the table and its indexes.
+//
+// Returns ErrDuplicateKey if the primary key is taken, or an error wrapping
+// ErrUniqueViolation if a unique index key is taken. Nothing is changed on
+// error.
+func (t *Table[PK, R]) Insert(record R) error {
+	pk := t.key(record)
+	if _, ok := t.rows[pk]; ok {
+		return ErrDuplicateKey
+	}
+	if err := t.check(record, nil); err != nil {
+		return err
+	}
+	t.seq++
+	r := &row[R]{record: record, seq: t.seq, keys: make([]any, len(t.indexes))}
+	t.rows[pk] = r
+	t.order.Put(r.seq, r)
+	for _, idx := range t.indexes {
+		idx.insert(r)
+	}
+	return nil
+}
+
+// Update replaces the record with the same primary key and moves it in
+// every index whose key changed. The record keeps its insertion position.
+// A pointer record may be changed in place before calling Update.
+//
+// Returns ErrNotFound if there is no such record, or an error wrapping
+// ErrUniqueViolation. Nothing is changed on error.
+func (t *Table[PK, R]) Update(record R) error {
+	r, ok := t.rows[t.key(record)]
+	if !ok {
+		return ErrNotFound
+	}
+	if err := t.check(record, r); err != nil {
+		return err
+	}
+	for _, idx := range t.indexes {
+		idx.remove(r)
+	}
+	r.record = record
+	for _, idx := range t.indexes {
+		idx.insert(r)
+	}
+	return nil
+}
+
+// Upsert updates the record with the same primary key, or inserts record if
+// there is none.
+//
+// Returns an error wrapping ErrUniqueViolation. Nothing is changed on error.
+func (t *Table[PK, R]) Upsert(record R) error {
+	if _, ok := t.rows[t.key(record)]; ok {
+		return t.Update(record)
+	}
+	return t.Insert(record)
+}
+
+// Delete removes the record with primary key pk from the table and its
+// indexes.
+//
+// Returns false if there was no such record.
+func (t *Table[PK, R]) Delete(pk PK) bool {
+	r, ok := t.rows[pk]
+	if !ok {
+		return false
+	}
+	for _, idx := range t.indexes {
+		idx.remove(r)
+	}
+	delete(t.rows, pk)
+	t.order.Delete(r.seq)
+	return true
+}
+
+// Get returns the record with primary key pk and true if it exists.
+func (t *Table[PK, R]) Get(pk PK) (R, bool) {
+	r, ok := t.rows[pk]
+	if !ok {
+		var zero R
+		return zero, false
+	}
+	return r.record, true
+}
+
+// Contains checks if records with all the given primary keys exist.
+func (t *Table[PK, R]) Contains(pks ...PK) bool {
+	for _, pk := range pks {
+		if _, ok := t.rows[pk]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Each calls fn for every record in insertion order until fn returns false.
+func (t *Table[PK, R]) Each(fn func(record R) bool) {
+	t.order.Each(func(_ uint64, r *row[R]) bool {
+		return fn(r.record)
+	})
+}
+
+// ToSlice returns the records in insertion order.
+func (t *Table[PK, R]) ToSlice() []R {
+	records := make([]R, 0, len(t.rows))
+	t.Each(func(record R) bool {
+		records = append(records, record)
+		return true
+	})
+	return records
+}
+
+// Len returns the number of records.
+func (t *Table[PK, R]) Len() int {
+	return len(t.rows)
+}
+
+// IsEmpty checks if the table has no records.
+func (t *Table[PK, R]) IsEmpty() bool {
+	return len(t.rows) == 0
+}
+
+// Clear removes all records from the table and its indexes.
+func (t *Table[PK, R]) Clear() {
+	t.rows = make(map[PK]*row[R])
+	t.order.Clear()
+	for _, idx := range t.indexes {
+		idx.clear()
+	}
+}
+
+// check verifies the unique indexes for record, ignoring the row it
+// replaces.
+func (t *Table[PK, R]) check(record R, self *row[R]) error {
+	for _, idx := range t.indexes {
+		if err := idx.check(record, self); err != nil {
+			return err
+		}
+	}
+	return nil
+}
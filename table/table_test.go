@@ -0,0 +1,166 @@
+package table
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type user struct {
+	ID     int
+	Email  string
+	Status string
+	Age    int
+}
+
+type users struct {
+	*Table[int, user]
+	email  *UniqueIndex[user, string]
+	status *HashIndex[user, string]
+	age    *SortedIndex[user, int]
+}
+
+func newUsers(records ...user) users {
+	u := users{
+		email:  NewUniqueIndex("email", func(u user) string { return u.Email }),
+		status: NewHashIndex("status", func(u user) string { return u.Status }),
+		age:    NewSortedIndex("age", func(u user) int { return u.Age }),
+	}
+	u.Table = New(func(u user) int { return u.ID }, u.email, u.status, u.age)
+	for _, r := range records {
+		if err := u.Insert(r); err != nil {
+			panic(err)
+		}
+	}
+	return u
+}
+
+func ids(records []user) []int {
+	result := make([]int, len(records))
+	for i, r := range records {
+		result[i] = r.ID
+	}
+	return result
+}
+
+func TestTable_Insert(t *testing.T) {
+	u := newUsers(user{1, "a@x", "active", 30}, user{2, "b@x", "active", 25})
+
+	if err := u.Insert(user{1, "c@x", "active", 40}); err != ErrDuplicateKey {
+		t.Errorf("Expected ErrDuplicateKey, but got %v", err)
+	}
+	if err := u.Insert(user{3, "a@x", "active", 40}); !errors.Is(err, ErrUniqueViolation) {
+		t.Errorf("Expected ErrUniqueViolation, but got %v", err)
+	}
+	if u.Len() != 2 || u.Contains(3) || u.Select(u.age.Eq(40)).Count() != 0 {
+		t.Errorf("Expected failed inserts to change nothing")
+	}
+
+	if r, ok := u.Get(2); !ok || r.Email != "b@x" {
+		t.Errorf("Expected b@x, but got %v", r)
+	}
+	if result := ids(u.ToSlice()); !reflect.DeepEqual(result, []int{1, 2}) {
+		t.Errorf("Expected [1 2], but got %v", result)
+	}
+}
+
+func TestTable_UpdateAndDelete(t *testing.T) {
+	u := newUsers(user{1, "a@x", "active", 30}, user{2, "b@x", "active", 25}, user{3, "c@x", "banned", 41})
+
+	if err := u.Update(user{2, "c@x", "active", 25}); !errors.Is(err, ErrUniqueViolation) {
+		t.Errorf("Expected ErrUniqueViolation, but got %v", err)
+	}
+	if err := u.Update(user{9, "z@x", "active", 1}); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, but got %v", err)
+	}
+
+	// Changing the email of a record to its own value is not a violation.
+	if err := u.Update(user{1, "a@x", "banned", 50}); err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	if result := ids(u.Select(u.status.Eq("banned")).All()); !reflect.DeepEqual(result, []int{1, 3}) {
+		t.Errorf("Expected [1 3], but got %v", result)
+	}
+	if result := ids(u.Select(u.status.Eq("active")).All()); !reflect.DeepEqual(result, []int{2}) {
+		t.Errorf("Expected [2], but got %v", result)
+	}
+	if result := ids(u.Select().OrderBy(u.age).All()); !reflect.DeepEqual(result, []int{2, 3, 1}) {
+		t.Errorf("Expected [2 3 1], but got %v", result)
+	}
+
+	if err := u.Upsert(user{4, "d@x", "active", 18}); err != nil || !u.Contains(4) {
+		t.Errorf("Expected Upsert to insert, but got %v", err)
+	}
+	if !u.Delete(1) || u.Delete(1) {
+		t.Errorf("Expected exactly one successful delete")
+	}
+	if _, ok := u.Select(u.email.Eq("a@x")).First(); ok {
+		t.Errorf("Expected the deleted record to leave the indexes")
+	}
+	if err := u.Insert(user{5, "a@x", "active", 60}); err != nil {
+		t.Errorf("Expected the deleted email to be free, but got %v", err)
+	}
+
+	u.Clear()
+	if !u.IsEmpty() || u.Select(u.age.AtLeast(0)).Count() != 0 {
+		t.Errorf("Expected Clear to empty the table and its indexes")
+	}
+}
+
+func TestTable_PointerRecords(t *testing.T) {
+	email := NewUniqueIndex("email", func(u *user) string { return u.Email })
+	status := NewHashIndex("status", func(u *user) string { return u.Status })
+	age := NewSortedIndex("age", func(u *user) int { return u.Age })
+	tbl := New(func(u *user) int { return u.ID }, email, status, age)
+	a, b := &user{1, "a@x", "active", 30}, &user{2, "b@x", "active", 25}
+	tbl.Insert(a)
+	tbl.Insert(b)
+
+	// The record is changed in place, so the old keys are only known to the
+	// table.
+	a.Email, a.Status, a.Age = "c@x", "banned", 20
+	if err := tbl.Update(a); err != nil {
+		t.Fatalf("Expected nil, but got %v", err)
+	}
+	if _, ok := tbl.Select(email.Eq("a@x")).First(); ok {
+		t.Errorf("Expected the old email to leave the index")
+	}
+	if r, ok := tbl.Select(email.Eq("c@x")).First(); !ok || r != a {
+		t.Errorf("Expected %v, but got %v", a, r)
+	}
+	if n := tbl.Select(status.Eq("active")).Count(); n != 1 {
+		t.Errorf("Expected 1, but got %v", n)
+	}
+	if r, ok := tbl.Select().OrderBy(age).First(); !ok || r != a {
+		t.Errorf("Expected %v, but got %v", a, r)
+	}
+	if err := tbl.Insert(&user{3, "a@x", "active", 40}); err != nil {
+		t.Errorf("Expected the old email to be free, but got %v", err)
+	}
+
+	b.Email, b.Status, b.Age = "d@x", "banned", 50
+	if !tbl.Delete(2) {
+		t.Fatalf("Expected the record to be deleted")
+	}
+	if n := tbl.Select(status.Eq("active")).Count(); n != 1 {
+		t.Errorf("Expected 1, but got %v", n)
+	}
+	if n := tbl.Select(age.Between(0, 100)).Count(); n != 2 {
+		t.Errorf("Expected 2, but got %v", n)
+	}
+	if err := tbl.Insert(&user{4, "b@x", "active", 25}); err != nil {
+		t.Errorf("Expected the deleted email to be free, but got %v", err)
+	}
+}
+
+func TestNew_Panics(t *testing.T) {
+	idx := NewHashIndex("status", func(u user) string { return u.Status })
+	New(func(u user) int { return u.ID }, idx)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic when an index is reused")
+		}
+	}()
+	New(func(u user) int { return u.ID }, idx)
+}